@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -59,12 +62,134 @@ type AuthConfig struct {
 	RedisURL       string
 	TokenExpiry    time.Duration
 	RefreshExpiry  time.Duration
+	// ImpersonationTokenExpiry is the lifetime of access tokens administrators impersonate users with
+	ImpersonationTokenExpiry time.Duration
+
+	// JWTSigningKeyFile is a PEM private key (RSA, ECDSA or Ed25519) used to sign tokens.
+	// When empty, tokens are signed with HS256 and JWTSecret.
+	JWTSigningKeyFile string
+	// JWTVerificationKeyFiles are retired PEM keys whose tokens are still accepted
+	JWTVerificationKeyFiles []string
+	// JWTAcceptHS256 keeps accepting tokens signed with JWTSecret after switching to
+	// JWTSigningKeyFile, until they have expired
+	JWTAcceptHS256 bool
+
+	// OIDCIssuer is the public base URL of the service, e.g. https://auth.example.com.
+	// OpenID Connect provider endpoints are only served when it is set.
+	OIDCIssuer string
+
+	// PasswordHashAlgorithm hashes new passwords: "argon2id" or "bcrypt". Hashes made with another
+	// algorithm or other parameters are replaced on the user's next successful login.
+	PasswordHashAlgorithm string
+	BcryptCost            int
+	// Argon2id parameters: memory in KiB, number of passes and degree of parallelism
+	Argon2Memory      int
+	Argon2Iterations  int
+	Argon2Parallelism int
+
+	// Password policy for new passwords: length bounds, how many of lowercase, uppercase, digits
+	// and symbols to mix, words that may not appear, and the lowest strength score from 0 to 4
+	PasswordMinLength           int
+	PasswordMaxLength           int
+	PasswordMinCharacterClasses int
+	PasswordBannedWords         []string
+	PasswordMinStrength         int
+	// BreachedPasswordsPath is a Pwned Passwords style dataset of SHA-1 hashes, either a directory
+	// of <PREFIX>.txt range files or a single file of hashes. When empty, passwords are not
+	// checked against breaches.
+	BreachedPasswordsPath string
+
+	// PasswordResetURL is the page that accepts reset tokens, e.g. https://app.example.com/reset-password.
+	// The token is appended as the token query parameter.
+	PasswordResetURL    string
+	PasswordResetExpiry time.Duration
+
+	// EmailVerificationURL is the page that accepts email verification tokens.
+	// The token is appended as the token query parameter.
+	EmailVerificationURL            string
+	EmailVerificationExpiry         time.Duration
+	EmailVerificationResendInterval time.Duration
+	// AllowUnverifiedLogin lets users log in before verifying their email address
+	AllowUnverifiedLogin bool
+
+	// EmailChangeURL is the page that accepts tokens confirming a new email address. The token is
+	// appended as the token query parameter and expires after EmailVerificationExpiry.
+	EmailChangeURL string
+
+	// OrganizationInvitationURL is the page that accepts organization invitation tokens. The token
+	// is appended as the token query parameter and expires after OrganizationInvitationExpiry.
+	OrganizationInvitationURL    string
+	OrganizationInvitationExpiry time.Duration
+
+	// AccountDeletionGracePeriod is how long users can cancel a requested account deletion before
+	// the account is irreversibly deleted. AccountDeletionInterval is how often the deletion job
+	// looks for due accounts; zero disables the job on this instance.
+	AccountDeletionGracePeriod time.Duration
+	AccountDeletionInterval    time.Duration
+
+	// Failed password logins allowed per account and per client IP within LoginAttemptWindow
+	// before logins are locked for LoginLockoutDuration; zero disables the limit
+	LoginMaxAttempts      int
+	LoginMaxAttemptsPerIP int
+	LoginAttemptWindow    time.Duration
+	LoginLockoutDuration  time.Duration
+	// LoginFailureDelay slows down failed logins, doubling with every failure of the same account
+	LoginFailureDelay time.Duration
+
+	// TOTPIssuer is the account issuer shown in authenticator apps
+	TOTPIssuer string
+
+	// WebAuthnRPID is the relying party ID passkeys are bound to, the registrable domain of the
+	// web app (e.g. example.com). WebAuthnOrigins are the exact origins ceremonies may come from.
+	WebAuthnRPID    string
+	WebAuthnRPName  string
+	WebAuthnOrigins []string
+
+	// MailOutboxDir makes the service write outgoing emails to files in this directory.
+	// When empty, emails are only logged.
+	MailOutboxDir string
+
+	// BootstrapAdminEmail names an existing user who is granted the admin role at startup
+	BootstrapAdminEmail string
+
+	// ExtAuthzPublicPaths are gateway paths that Envoy may forward without a bearer token.
+	// Entries ending in "*" match by prefix.
+	ExtAuthzPublicPaths []string
+
+	// TrustedProxies are the CIDRs of reverse proxies whose X-Forwarded-For headers are believed
+	TrustedProxies []string
 }
 
-// LoadAuthConfig loads auth service specific configuration
-func LoadAuthConfig() *AuthConfig {
-	tokenExpiry, _ := time.ParseDuration(getEnv("JWT_ACCESS_TOKEN_EXPIRY", "15m"))
-	refreshExpiry, _ := time.ParseDuration(getEnv("JWT_REFRESH_TOKEN_EXPIRY", "7d"))
+// LoadAuthConfig loads auth service specific configuration. Durations that time.ParseDuration
+// cannot read are reported instead of silently becoming zero.
+func LoadAuthConfig() (*AuthConfig, error) {
+	durations := &durationParser{}
+	tokenExpiry := durations.get("JWT_ACCESS_TOKEN_EXPIRY", "15m")
+	refreshExpiry := durations.get("JWT_REFRESH_TOKEN_EXPIRY", "168h")
+	impersonationExpiry := durations.get("IMPERSONATION_TOKEN_EXPIRY", "15m")
+	resetExpiry := durations.get("PASSWORD_RESET_TOKEN_EXPIRY", "1h")
+	bcryptCost, _ := strconv.Atoi(getEnv("BCRYPT_COST", "10"))
+	argon2Memory, _ := strconv.Atoi(getEnv("ARGON2_MEMORY", "19456"))
+	argon2Iterations, _ := strconv.Atoi(getEnv("ARGON2_ITERATIONS", "2"))
+	argon2Parallelism, _ := strconv.Atoi(getEnv("ARGON2_PARALLELISM", "1"))
+	passwordMinLength, _ := strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", "8"))
+	passwordMaxLength, _ := strconv.Atoi(getEnv("PASSWORD_MAX_LENGTH", "72"))
+	passwordMinClasses, _ := strconv.Atoi(getEnv("PASSWORD_MIN_CHARACTER_CLASSES", "0"))
+	passwordMinStrength, _ := strconv.Atoi(getEnv("PASSWORD_MIN_STRENGTH", "2"))
+	verifyExpiry := durations.get("EMAIL_VERIFICATION_TOKEN_EXPIRY", "24h")
+	verifyResendInterval := durations.get("EMAIL_VERIFICATION_RESEND_INTERVAL", "1m")
+	loginMaxAttempts, _ := strconv.Atoi(getEnv("LOGIN_MAX_ATTEMPTS", "5"))
+	loginMaxAttemptsPerIP, _ := strconv.Atoi(getEnv("LOGIN_MAX_ATTEMPTS_PER_IP", "100"))
+	loginAttemptWindow := durations.get("LOGIN_ATTEMPT_WINDOW", "15m")
+	loginLockoutDuration := durations.get("LOGIN_LOCKOUT_DURATION", "15m")
+	loginFailureDelay := durations.get("LOGIN_FAILURE_DELAY", "250ms")
+	invitationExpiry := durations.get("ORGANIZATION_INVITATION_EXPIRY", "168h")
+	deletionGracePeriod := durations.get("ACCOUNT_DELETION_GRACE_PERIOD", "720h")
+	deletionInterval := durations.get("ACCOUNT_DELETION_INTERVAL", "1h")
+
+	if err := durations.err(); err != nil {
+		return nil, err
+	}
 
 	return &AuthConfig{
 		JWTSecret:     getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
@@ -74,6 +199,105 @@ func LoadAuthConfig() *AuthConfig {
 		RedisURL:      getEnv("REDIS_URL", "redis://localhost:6379"),
 		TokenExpiry:   tokenExpiry,
 		RefreshExpiry: refreshExpiry,
+
+		ImpersonationTokenExpiry: impersonationExpiry,
+
+		JWTSigningKeyFile:       getEnv("JWT_SIGNING_KEY_FILE", ""),
+		JWTVerificationKeyFiles: getEnvList("JWT_VERIFICATION_KEY_FILES"),
+		JWTAcceptHS256:          getEnv("JWT_ACCEPT_HS256", "false") == "true",
+
+		OIDCIssuer: strings.TrimSuffix(getEnv("OIDC_ISSUER", ""), "/"),
+
+		PasswordHashAlgorithm: getEnv("PASSWORD_HASH_ALGORITHM", "argon2id"),
+		BcryptCost:            bcryptCost,
+		Argon2Memory:          argon2Memory,
+		Argon2Iterations:      argon2Iterations,
+		Argon2Parallelism:     argon2Parallelism,
+
+		PasswordMinLength:           passwordMinLength,
+		PasswordMaxLength:           passwordMaxLength,
+		PasswordMinCharacterClasses: passwordMinClasses,
+		PasswordBannedWords:         getEnvList("PASSWORD_BANNED_WORDS"),
+		PasswordMinStrength:         passwordMinStrength,
+		BreachedPasswordsPath:       getEnv("BREACHED_PASSWORDS_PATH", ""),
+
+		PasswordResetURL:    getEnv("PASSWORD_RESET_URL", ""),
+		PasswordResetExpiry: resetExpiry,
+
+		EmailVerificationURL:            getEnv("EMAIL_VERIFICATION_URL", ""),
+		EmailVerificationExpiry:         verifyExpiry,
+		EmailVerificationResendInterval: verifyResendInterval,
+		AllowUnverifiedLogin:            getEnv("ALLOW_UNVERIFIED_LOGIN", "false") == "true",
+
+		EmailChangeURL: getEnv("EMAIL_CHANGE_URL", ""),
+
+		OrganizationInvitationURL:    getEnv("ORGANIZATION_INVITATION_URL", ""),
+		OrganizationInvitationExpiry: invitationExpiry,
+
+		AccountDeletionGracePeriod: deletionGracePeriod,
+		AccountDeletionInterval:    deletionInterval,
+
+		LoginMaxAttempts:      loginMaxAttempts,
+		LoginMaxAttemptsPerIP: loginMaxAttemptsPerIP,
+		LoginAttemptWindow:    loginAttemptWindow,
+		LoginLockoutDuration:  loginLockoutDuration,
+		LoginFailureDelay:     loginFailureDelay,
+
+		TOTPIssuer: getEnv("TOTP_ISSUER", "go-factory"),
+
+		WebAuthnRPID:    getEnv("WEBAUTHN_RP_ID", "localhost"),
+		WebAuthnRPName:  getEnv("WEBAUTHN_RP_NAME", "go-factory"),
+		WebAuthnOrigins: getEnvListOrDefault("WEBAUTHN_ORIGINS", []string{"http://localhost:3000"}),
+
+		MailOutboxDir: getEnv("MAIL_OUTBOX_DIR", ""),
+
+		BootstrapAdminEmail: getEnv("BOOTSTRAP_ADMIN_EMAIL", ""),
+
+		ExtAuthzPublicPaths: getEnvListOrDefault("EXT_AUTHZ_PUBLIC_PATHS", []string{
+			"/health",
+			"/api/v1/auth/login",
+			"/api/v1/auth/register",
+			"/api/v1/auth/refresh",
+			"/api/v1/auth/password/*",
+			"/api/v1/auth/email/*",
+			"/api/v1/auth/mfa/verify",
+			"/api/v1/auth/passkeys/login/*",
+			"/.well-known/*",
+			"/oauth2/*",
+			"/userinfo",
+			"/auth.v1.AuthService/*",
+		}),
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+	}, nil
+}
+
+// RateLimitConfig holds request rate limiting configuration
+type RateLimitConfig struct {
+	// Algorithm is "sliding_window" or "token_bucket"
+	Algorithm string
+	// Requests allowed per Window; zero disables rate limiting
+	Requests int
+	Window   time.Duration
+	// Burst is the token bucket size; zero means Requests
+	Burst int
+}
+
+// LoadRateLimitConfig loads rate limiting configuration
+func LoadRateLimitConfig() *RateLimitConfig {
+	requests, _ := strconv.Atoi(getEnv("RATE_LIMIT_REQUESTS", "100"))
+	window, _ := time.ParseDuration(getEnv("RATE_LIMIT_WINDOW", "1m"))
+	burst, _ := strconv.Atoi(getEnv("RATE_LIMIT_BURST", "0"))
+
+	if window <= 0 {
+		window = time.Minute
+	}
+
+	return &RateLimitConfig{
+		Algorithm: getEnv("RATE_LIMIT_ALGORITHM", "sliding_window"),
+		Requests:  requests,
+		Window:    window,
+		Burst:     burst,
 	}
 }
 
@@ -95,6 +319,43 @@ func LoadFeedConfig() *FeedConfig {
 	}
 }
 
+// durationParser reads duration settings, collecting an error for every one that is not valid
+type durationParser struct {
+	errs []error
+}
+
+func (p *durationParser) get(key, fallback string) time.Duration {
+	value := getEnv(key, fallback)
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s: invalid duration %q, use units such as 15m or 168h", key, value))
+	}
+	return duration
+}
+
+func (p *durationParser) err() error {
+	return errors.Join(p.errs...)
+}
+
+// getEnvList gets a comma-separated environment variable as a list, skipping empty items
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvListOrDefault is getEnvList with a fallback used when the variable is unset or empty
+func getEnvListOrDefault(key string, fallback []string) []string {
+	if values := getEnvList(key); len(values) > 0 {
+		return values
+	}
+	return fallback
+}
+
 // getEnv gets environment variable with fallback
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadAuthConfigDurations(t *testing.T) {
+	t.Setenv("JWT_ACCESS_TOKEN_EXPIRY", "")
+	t.Setenv("JWT_REFRESH_TOKEN_EXPIRY", "")
+
+	cfg, err := LoadAuthConfig()
+	if err != nil {
+		t.Fatalf("LoadAuthConfig() with defaults failed: %v", err)
+	}
+	if cfg.TokenExpiry != 15*time.Minute {
+		t.Errorf("TokenExpiry = %v, want 15m", cfg.TokenExpiry)
+	}
+	if cfg.RefreshExpiry != 7*24*time.Hour {
+		t.Errorf("RefreshExpiry = %v, want 168h", cfg.RefreshExpiry)
+	}
+
+	t.Setenv("JWT_REFRESH_TOKEN_EXPIRY", "7d")
+	t.Setenv("LOGIN_LOCKOUT_DURATION", "soon")
+	if _, err := LoadAuthConfig(); err == nil {
+		t.Fatal("LoadAuthConfig() accepted invalid durations")
+	} else {
+		for _, key := range []string{"JWT_REFRESH_TOKEN_EXPIRY", "LOGIN_LOCKOUT_DURATION"} {
+			if !strings.Contains(err.Error(), key) {
+				t.Errorf("error %q does not name %s", err, key)
+			}
+		}
+	}
+}
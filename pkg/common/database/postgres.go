@@ -76,7 +76,7 @@ func (db *DB) Health() error {
 }
 
 // Transaction executes a function within a database transaction
-func (db *DB) Transaction(fn func(*sql.Tx) error) error {
+func (db *DB) Transaction(fn func(*sql.Tx) error) (err error) {
 	tx, err := db.Begin()
 	if err != nil {
 		return err
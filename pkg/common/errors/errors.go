@@ -14,17 +14,23 @@ const (
 	ErrForbidden       ErrorCode = "FORBIDDEN"
 	ErrInvalidToken    ErrorCode = "INVALID_TOKEN"
 	ErrExpiredToken    ErrorCode = "EXPIRED_TOKEN"
+	ErrEmailNotVerified ErrorCode = "EMAIL_NOT_VERIFIED"
+	ErrAccountLocked   ErrorCode = "ACCOUNT_LOCKED"
 	
 	// Validation errors
 	ErrValidation      ErrorCode = "VALIDATION_ERROR"
 	ErrInvalidInput    ErrorCode = "INVALID_INPUT"
 	ErrMissingField    ErrorCode = "MISSING_FIELD"
+	ErrPayloadTooLarge ErrorCode = "PAYLOAD_TOO_LARGE"
 	
 	// Resource errors
 	ErrNotFound        ErrorCode = "NOT_FOUND"
 	ErrAlreadyExists   ErrorCode = "ALREADY_EXISTS"
 	ErrConflict        ErrorCode = "CONFLICT"
 	
+	// Throttling errors
+	ErrTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
+	
 	// Server errors
 	ErrInternal        ErrorCode = "INTERNAL_ERROR"
 	ErrServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
@@ -72,14 +78,20 @@ func getHTTPStatusForCode(code ErrorCode) int {
 	switch code {
 	case ErrUnauthorized, ErrInvalidToken, ErrExpiredToken:
 		return http.StatusUnauthorized
-	case ErrForbidden:
+	case ErrForbidden, ErrEmailNotVerified:
 		return http.StatusForbidden
+	case ErrAccountLocked:
+		return http.StatusLocked
 	case ErrNotFound:
 		return http.StatusNotFound
 	case ErrValidation, ErrInvalidInput, ErrMissingField:
 		return http.StatusBadRequest
+	case ErrPayloadTooLarge:
+		return http.StatusRequestEntityTooLarge
 	case ErrAlreadyExists, ErrConflict:
 		return http.StatusConflict
+	case ErrTooManyRequests:
+		return http.StatusTooManyRequests
 	case ErrServiceUnavailable:
 		return http.StatusServiceUnavailable
 	case ErrInternal, ErrDatabaseError, ErrExternalService:
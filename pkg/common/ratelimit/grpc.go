@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCKeyFunc identifies the client an RPC is counted against; an empty key means it does not apply
+type GRPCKeyFunc func(ctx context.Context, info *grpc.UnaryServerInfo) string
+
+// GRPCKeyByIP keys RPCs by the address of the connecting peer
+func GRPCKeyByIP(ctx context.Context, _ *grpc.UnaryServerInfo) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return "ip:" + host
+}
+
+// GRPCKeyByUserID keys RPCs by the authenticated user, as stored in the "userID" context value
+func GRPCKeyByUserID(ctx context.Context, _ *grpc.UnaryServerInfo) string {
+	if userID, ok := ctx.Value("userID").(string); ok && userID != "" {
+		return "user:" + userID
+	}
+	return ""
+}
+
+// GRPCKeyByAPIKey keys RPCs by the API key in the given metadata entry
+func GRPCKeyByAPIKey(name string) GRPCKeyFunc {
+	return func(ctx context.Context, _ *grpc.UnaryServerInfo) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(name); len(values) > 0 {
+			return apiKeyKey(values[0])
+		}
+		return ""
+	}
+}
+
+// UnaryServerInterceptor is the gRPC counterpart of Middleware. The RateLimit-* values are sent
+// as lower-case response headers; rejected calls fail with ResourceExhausted.
+func UnaryServerInterceptor(limiter Limiter, keyFuncs ...GRPCKeyFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := ""
+		for _, keyFunc := range keyFuncs {
+			if key = keyFunc(ctx, info); key != "" {
+				break
+			}
+		}
+		if key == "" {
+			return handler(ctx, req)
+		}
+
+		result, err := limiter.Allow(ctx, key)
+		if err != nil {
+			return handler(ctx, req)
+		}
+
+		md := metadata.MD{}
+		for name, value := range result.Headers() {
+			md.Set(strings.ToLower(name), value)
+		}
+		_ = grpc.SetHeader(ctx, md)
+
+		if !result.Allowed {
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+
+		return handler(ctx, req)
+	}
+}
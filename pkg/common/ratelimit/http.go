@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/errors"
+	"github.com/VariableSan/go-factory-microservice/pkg/common/response"
+)
+
+// KeyFunc identifies the client a request is counted against; an empty key means it does not apply
+type KeyFunc func(r *http.Request) string
+
+// KeyByIP keys requests by the address of the connecting peer. Behind a reverse proxy, use a
+// KeyFunc that resolves the original client address instead.
+func KeyByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if host == "" {
+		return ""
+	}
+	return "ip:" + host
+}
+
+// KeyByUserID keys requests by the authenticated user, as stored in the "userID" context value
+// by the auth middleware
+func KeyByUserID(r *http.Request) string {
+	if userID, ok := r.Context().Value("userID").(string); ok && userID != "" {
+		return "user:" + userID
+	}
+	return ""
+}
+
+// KeyByAPIKey keys requests by the API key in the given header. The key is hashed so it is never
+// stored in Redis.
+func KeyByAPIKey(header string) KeyFunc {
+	return func(r *http.Request) string {
+		return apiKeyKey(r.Header.Get(header))
+	}
+}
+
+// Middleware limits requests with limiter, keyed by the first of keyFuncs that yields a key.
+// Requests without any key are not limited. Every limited response carries the RateLimit-*
+// headers; rejected requests get 429 Too Many Requests with Retry-After.
+func Middleware(limiter Limiter, keyFuncs ...KeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := ""
+			for _, keyFunc := range keyFuncs {
+				if key = keyFunc(r); key != "" {
+					break
+				}
+			}
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := limiter.Allow(r.Context(), key)
+			if err != nil {
+				// Never fail requests because the limiter is broken
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for name, value := range result.Headers() {
+				w.Header().Set(name, value)
+			}
+
+			if !result.Allowed {
+				response.Error(w, errors.NewAppError(errors.ErrTooManyRequests, "Rate limit exceeded"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func apiKeyKey(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return "apikey:" + hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often expired in-memory entries are dropped
+const sweepInterval = time.Minute
+
+// memoryStore keeps rate limit state in process memory. It backs the limiters when Redis is not
+// configured or unreachable; limits then apply per instance instead of across the cluster.
+type memoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*memoryCounter
+	buckets   map[string]*memoryBucket
+	nextSweep time.Time
+}
+
+type memoryCounter struct {
+	value     int64
+	expiresAt time.Time
+}
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	expiresAt time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		counters: make(map[string]*memoryCounter),
+		buckets:  make(map[string]*memoryBucket),
+	}
+}
+
+// slide counts a request in the window at currentKey if the count of the previous window,
+// weighted by weight, plus the current count leaves room for it under limit. It returns whether
+// it did and both counts.
+func (m *memoryStore) slide(previousKey, currentKey string, limit int, weight float64, now time.Time, ttl time.Duration) (bool, int64, int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+
+	previous := m.count(previousKey, now)
+	current := m.count(currentKey, now)
+	if float64(previous)*weight+float64(current)+1 > float64(limit) {
+		return false, previous, current
+	}
+
+	counter, ok := m.counters[currentKey]
+	if !ok || now.After(counter.expiresAt) {
+		counter = &memoryCounter{}
+		m.counters[currentKey] = counter
+	}
+	counter.value++
+	counter.expiresAt = now.Add(ttl)
+
+	return true, previous, counter.value
+}
+
+// count is the value of an unexpired counter; the caller holds the lock
+func (m *memoryStore) count(key string, now time.Time) int64 {
+	counter, ok := m.counters[key]
+	if !ok || now.After(counter.expiresAt) {
+		return 0
+	}
+	return counter.value
+}
+
+// take refills key's bucket at rate tokens per millisecond and takes a token if one is available,
+// returning whether it did and the tokens left
+func (m *memoryStore) take(key string, capacity, rate float64, now time.Time, ttl time.Duration) (bool, float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+
+	bucket, ok := m.buckets[key]
+	if !ok || now.After(bucket.expiresAt) {
+		bucket = &memoryBucket{tokens: capacity, updatedAt: now}
+		m.buckets[key] = bucket
+	}
+	if elapsed := now.Sub(bucket.updatedAt); elapsed > 0 {
+		bucket.tokens = math.Min(capacity, bucket.tokens+float64(elapsed.Milliseconds())*rate)
+		bucket.updatedAt = now
+	}
+	bucket.expiresAt = now.Add(ttl)
+
+	if bucket.tokens < 1 {
+		return false, bucket.tokens
+	}
+	bucket.tokens--
+	return true, bucket.tokens
+}
+
+// sweep drops expired entries; the caller holds the lock
+func (m *memoryStore) sweep(now time.Time) {
+	if now.Before(m.nextSweep) {
+		return
+	}
+	m.nextSweep = now.Add(sweepInterval)
+
+	for key, counter := range m.counters {
+		if now.After(counter.expiresAt) {
+			delete(m.counters, key)
+		}
+	}
+	for key, bucket := range m.buckets {
+		if now.After(bucket.expiresAt) {
+			delete(m.buckets, key)
+		}
+	}
+}
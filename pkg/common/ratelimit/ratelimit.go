@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/config"
+	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
+)
+
+// Supported algorithms
+const (
+	AlgorithmSlidingWindow = "sliding_window"
+	AlgorithmTokenBucket   = "token_bucket"
+)
+
+// Standard rate limit response headers (IETF draft "RateLimit header fields for HTTP")
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Limiter decides whether a request identified by key may proceed
+type Limiter interface {
+	Allow(ctx context.Context, key string) (*Result, error)
+}
+
+// Result is the outcome of a rate limit check
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the full quota is available again
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed; zero when Allowed
+	RetryAfter time.Duration
+}
+
+// Headers returns the RateLimit-* headers for the result, plus Retry-After when it was rejected
+func (r *Result) Headers() map[string]string {
+	headers := map[string]string{
+		HeaderLimit:     strconv.Itoa(r.Limit),
+		HeaderRemaining: strconv.Itoa(r.Remaining),
+		HeaderReset:     strconv.FormatInt(seconds(r.Reset), 10),
+	}
+	if !r.Allowed {
+		retryAfter := seconds(r.RetryAfter)
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		headers[HeaderRetryAfter] = strconv.FormatInt(retryAfter, 10)
+	}
+	return headers
+}
+
+// New creates the limiter selected by cfg, storing its state in Redis under the given name.
+// client may be nil, in which case every instance keeps its own counts in memory.
+func New(client *redis.Client, name string, cfg *config.RateLimitConfig) (Limiter, error) {
+	switch cfg.Algorithm {
+	case AlgorithmSlidingWindow, "":
+		return NewSlidingWindow(client, name, cfg.Requests, cfg.Window), nil
+	case AlgorithmTokenBucket:
+		return NewTokenBucket(client, name, cfg.Requests, cfg.Window, cfg.Burst), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit algorithm %q", cfg.Algorithm)
+	}
+}
+
+// redisRetryInterval is how long limiters count in memory after Redis failed
+const redisRetryInterval = 10 * time.Second
+
+// breaker skips Redis for a while after it failed, so an outage does not slow every request down
+type breaker struct {
+	mu        sync.Mutex
+	openUntil time.Time
+}
+
+func (b *breaker) available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+func (b *breaker) fail() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.openUntil = time.Now().Add(redisRetryInterval)
+}
+
+// seconds rounds a duration up to whole seconds
+func seconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
+
+func storageKey(name, key string) string {
+	return fmt.Sprintf("ratelimit:%s:%s", name, key)
+}
@@ -0,0 +1,254 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/config"
+)
+
+func TestSlidingWindowAllow(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		requests int
+		// wantAllowed is how many of the requests are allowed, all before any rejection
+		wantAllowed int
+	}{
+		{name: "under the limit", limit: 5, requests: 3, wantAllowed: 3},
+		{name: "at the limit", limit: 5, requests: 5, wantAllowed: 5},
+		{name: "over the limit", limit: 5, requests: 8, wantAllowed: 5},
+		{name: "limit of one", limit: 1, requests: 2, wantAllowed: 1},
+		{name: "zero limit", limit: 0, requests: 1, wantAllowed: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewSlidingWindow(nil, "test", tt.limit, time.Hour)
+			for i := 0; i < tt.requests; i++ {
+				result, err := limiter.Allow(context.Background(), "client")
+				if err != nil {
+					t.Fatalf("Allow() error = %v", err)
+				}
+				wantAllowed := i < tt.wantAllowed
+				if result.Allowed != wantAllowed {
+					t.Fatalf("request %d: Allowed = %v, want %v", i+1, result.Allowed, wantAllowed)
+				}
+				if result.Allowed && result.Remaining != tt.limit-i-1 {
+					t.Errorf("request %d: Remaining = %d, want %d", i+1, result.Remaining, tt.limit-i-1)
+				}
+				if !result.Allowed && (result.Remaining != 0 || result.RetryAfter <= 0) {
+					t.Errorf("request %d: rejected with Remaining = %d, RetryAfter = %v", i+1, result.Remaining, result.RetryAfter)
+				}
+			}
+
+			// Other keys have their own counts
+			if tt.limit > 0 {
+				if result, err := limiter.Allow(context.Background(), "other"); err != nil || !result.Allowed {
+					t.Errorf("Allow(other) = %+v, %v, want allowed", result, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSlide(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		previous     int
+		current      int
+		weight       float64
+		limit        int
+		wantAllowed  bool
+		wantPrevious int64
+		wantCurrent  int64
+	}{
+		{name: "empty windows", limit: 2, weight: 1, wantAllowed: true, wantCurrent: 1},
+		{name: "current window full", current: 2, limit: 2, weight: 0, wantCurrent: 2},
+		{name: "previous window fully weighted", previous: 2, limit: 2, weight: 1, wantPrevious: 2},
+		{name: "previous window half weighted", previous: 2, current: 0, limit: 2, weight: 0.5, wantAllowed: true, wantPrevious: 2, wantCurrent: 1},
+		{name: "weighted estimate just over the limit", previous: 3, current: 1, limit: 4, weight: 0.7, wantPrevious: 3, wantCurrent: 1},
+		{name: "weighted estimate exactly at the limit", previous: 4, current: 1, limit: 4, weight: 0.5, wantAllowed: true, wantPrevious: 4, wantCurrent: 2},
+		{name: "previous window expired", previous: 10, limit: 2, weight: 0, wantAllowed: true, wantPrevious: 10, wantCurrent: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			store.counters["previous"] = &memoryCounter{value: int64(tt.previous), expiresAt: now.Add(time.Hour)}
+			store.counters["current"] = &memoryCounter{value: int64(tt.current), expiresAt: now.Add(time.Hour)}
+
+			allowed, previous, current := store.slide("previous", "current", tt.limit, tt.weight, now, time.Hour)
+			if allowed != tt.wantAllowed || previous != tt.wantPrevious || current != tt.wantCurrent {
+				t.Errorf("slide() = %v, %d, %d, want %v, %d, %d", allowed, previous, current, tt.wantAllowed, tt.wantPrevious, tt.wantCurrent)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSlideExpiry(t *testing.T) {
+	store := newMemoryStore()
+	now := time.Now()
+
+	if allowed, _, _ := store.slide("previous", "current", 1, 1, now, time.Minute); !allowed {
+		t.Fatal("first request rejected")
+	}
+	if allowed, _, _ := store.slide("previous", "current", 1, 1, now.Add(time.Second), time.Minute); allowed {
+		t.Fatal("second request allowed within the expiry")
+	}
+	if allowed, _, current := store.slide("previous", "current", 1, 1, now.Add(2*time.Minute), time.Minute); !allowed || current != 1 {
+		t.Fatalf("request after the expiry = %v with count %d, want allowed with count 1", allowed, current)
+	}
+}
+
+func TestSlidingWindowRetryAfter(t *testing.T) {
+	limiter := NewSlidingWindow(nil, "test", 10, time.Minute)
+
+	tests := []struct {
+		name     string
+		previous int64
+		current  int64
+		elapsed  time.Duration
+		want     time.Duration
+	}{
+		{name: "current window full", previous: 0, current: 10, elapsed: 20 * time.Second, want: 40 * time.Second},
+		{name: "no previous requests", previous: 0, current: 9, elapsed: 20 * time.Second, want: 40 * time.Second},
+		{name: "previous window drains", previous: 10, current: 4, elapsed: 15 * time.Second, want: 15 * time.Second},
+		{name: "already drained", previous: 10, current: 0, elapsed: 30 * time.Second, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limiter.retryAfter(tt.previous, tt.current, tt.elapsed); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	now := time.Now()
+	// One token per second
+	const rate = 0.001
+
+	tests := []struct {
+		name        string
+		tokens      float64
+		elapsed     time.Duration
+		wantAllowed bool
+		wantTokens  float64
+	}{
+		{name: "full bucket", tokens: 3, wantAllowed: true, wantTokens: 2},
+		{name: "last token", tokens: 1, wantAllowed: true, wantTokens: 0},
+		{name: "empty bucket", tokens: 0.5, wantTokens: 0.5},
+		{name: "refilled token", tokens: 0, elapsed: time.Second, wantAllowed: true, wantTokens: 0},
+		{name: "partially refilled", tokens: 0, elapsed: 500 * time.Millisecond, wantTokens: 0.5},
+		{name: "refill capped at capacity", tokens: 2, elapsed: time.Minute, wantAllowed: true, wantTokens: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			store.buckets["key"] = &memoryBucket{tokens: tt.tokens, updatedAt: now, expiresAt: now.Add(time.Hour)}
+
+			allowed, tokens := store.take("key", 3, rate, now.Add(tt.elapsed), time.Hour)
+			if allowed != tt.wantAllowed || tokens != tt.wantTokens {
+				t.Errorf("take() = %v, %v, want %v, %v", allowed, tokens, tt.wantAllowed, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestTokenBucketAllow(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       int
+		burst       int
+		requests    int
+		wantAllowed int
+	}{
+		{name: "burst defaults to the limit", limit: 3, requests: 5, wantAllowed: 3},
+		{name: "burst above the limit", limit: 3, burst: 5, requests: 7, wantAllowed: 5},
+		{name: "burst below the limit", limit: 3, burst: 1, requests: 2, wantAllowed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewTokenBucket(nil, "test", tt.limit, time.Hour, tt.burst)
+			for i := 0; i < tt.requests; i++ {
+				result, err := limiter.Allow(context.Background(), "client")
+				if err != nil {
+					t.Fatalf("Allow() error = %v", err)
+				}
+				if wantAllowed := i < tt.wantAllowed; result.Allowed != wantAllowed {
+					t.Fatalf("request %d: Allowed = %v, want %v", i+1, result.Allowed, wantAllowed)
+				}
+				if !result.Allowed && result.RetryAfter <= 0 {
+					t.Errorf("request %d: rejected with RetryAfter = %v", i+1, result.RetryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestResultHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		result Result
+		want   map[string]string
+	}{
+		{
+			name:   "allowed",
+			result: Result{Allowed: true, Limit: 10, Remaining: 4, Reset: 1500 * time.Millisecond},
+			want:   map[string]string{HeaderLimit: "10", HeaderRemaining: "4", HeaderReset: "2"},
+		},
+		{
+			name:   "rejected",
+			result: Result{Limit: 10, Reset: time.Minute, RetryAfter: 2500 * time.Millisecond},
+			want:   map[string]string{HeaderLimit: "10", HeaderRemaining: "0", HeaderReset: "60", HeaderRetryAfter: "3"},
+		},
+		{
+			name:   "rejected with retry under a second",
+			result: Result{Limit: 10, Reset: time.Minute},
+			want:   map[string]string{HeaderLimit: "10", HeaderRemaining: "0", HeaderReset: "60", HeaderRetryAfter: "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.result.Headers()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Headers() = %v, want %v", got, tt.want)
+			}
+			for header, value := range tt.want {
+				if got[header] != value {
+					t.Errorf("Headers()[%s] = %q, want %q", header, got[header], value)
+				}
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		wantErr   bool
+	}{
+		{algorithm: ""},
+		{algorithm: AlgorithmSlidingWindow},
+		{algorithm: AlgorithmTokenBucket},
+		{algorithm: "leaky_bucket", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			_, err := New(nil, "test", &config.RateLimitConfig{Algorithm: tt.algorithm, Requests: 10, Window: time.Minute})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
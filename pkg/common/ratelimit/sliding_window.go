@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// slidingWindowScript weighs the previous window's count and counts the request in the current
+// window if the estimate stays within the limit, atomically so that concurrent requests cannot
+// all pass the check before any of them is counted. It returns whether the request was counted
+// and both window counts.
+var slidingWindowScript = goredis.NewScript(`
+local limit = tonumber(ARGV[1])
+local weight = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+local previous = tonumber(redis.call('GET', KEYS[1]) or '0')
+local current = tonumber(redis.call('GET', KEYS[2]) or '0')
+
+if previous * weight + current + 1 > limit then
+	return {0, previous, current}
+end
+
+current = redis.call('INCR', KEYS[2])
+redis.call('PEXPIRE', KEYS[2], ttl)
+return {1, previous, current}
+`)
+
+// SlidingWindow allows limit requests per window. It keeps one counter per fixed window and
+// weighs the previous window's count by how much of it still overlaps the sliding window, which
+// smooths out the bursts fixed windows allow at their boundaries.
+type SlidingWindow struct {
+	redis   *redis.Client
+	breaker breaker
+	memory  *memoryStore
+	name    string
+	limit   int
+	window  time.Duration
+}
+
+// NewSlidingWindow creates a sliding window limiter. Counters live in Redis when client is set;
+// while Redis is unavailable each instance counts in memory instead.
+func NewSlidingWindow(client *redis.Client, name string, limit int, window time.Duration) *SlidingWindow {
+	return &SlidingWindow{
+		redis:  client,
+		memory: newMemoryStore(),
+		name:   name,
+		limit:  limit,
+		window: window,
+	}
+}
+
+// Allow counts a request for key if it fits in the window
+func (l *SlidingWindow) Allow(ctx context.Context, key string) (*Result, error) {
+	now := time.Now()
+	index := now.UnixNano() / int64(l.window)
+	elapsed := time.Duration(now.UnixNano() - index*int64(l.window))
+	weight := 1 - float64(elapsed)/float64(l.window)
+
+	currentKey := fmt.Sprintf("%s:%d", storageKey(l.name, key), index)
+	previousKey := fmt.Sprintf("%s:%d", storageKey(l.name, key), index-1)
+
+	if l.redis != nil && l.breaker.available() {
+		allowed, previous, current, err := l.slideRedis(ctx, previousKey, currentKey, weight)
+		if err == nil {
+			return l.result(allowed, previous, current, elapsed), nil
+		}
+		l.breaker.fail()
+	}
+
+	// Without Redis, count locally rather than rejecting or admitting everything
+	allowed, previous, current := l.memory.slide(previousKey, currentKey, l.limit, weight, time.Now(), 2*l.window)
+	return l.result(allowed, previous, current, elapsed), nil
+}
+
+func (l *SlidingWindow) slideRedis(ctx context.Context, previousKey, currentKey string, weight float64) (bool, int64, int64, error) {
+	reply, err := slidingWindowScript.Run(ctx, l.redis.Client, []string{previousKey, currentKey},
+		l.limit, strconv.FormatFloat(weight, 'f', -1, 64), (2 * l.window).Milliseconds(),
+	).Slice()
+	if err != nil {
+		return false, 0, 0, err
+	}
+	if len(reply) != 3 {
+		return false, 0, 0, fmt.Errorf("unexpected sliding window reply: %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	previous, _ := reply[1].(int64)
+	current, _ := reply[2].(int64)
+	return allowed == 1, previous, current, nil
+}
+
+// result describes a request given both window counts, the current one including the request if
+// it was allowed
+func (l *SlidingWindow) result(allowed bool, previous, current int64, elapsed time.Duration) *Result {
+	result := &Result{Allowed: allowed, Limit: l.limit, Reset: l.window - elapsed}
+	if !allowed {
+		result.RetryAfter = l.retryAfter(previous, current, elapsed)
+		return result
+	}
+
+	weight := 1 - float64(elapsed)/float64(l.window)
+	result.Remaining = l.limit - int(math.Ceil(float64(previous)*weight+float64(current)))
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+	return result
+}
+
+// retryAfter estimates when the weighted count drops enough to admit one more request
+func (l *SlidingWindow) retryAfter(previous, current int64, elapsed time.Duration) time.Duration {
+	free := float64(int64(l.limit) - current - 1)
+	if free < 0 || previous == 0 {
+		return l.window - elapsed
+	}
+	// previous * (1 - t/window) <= free  =>  t >= window * (1 - free/previous)
+	wait := time.Duration(float64(l.window)*(1-free/float64(previous))) - elapsed
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// tokenBucketScript refills and takes a token atomically, using the Redis clock so that all
+// instances agree on the time. It returns whether a token was taken and the tokens left.
+var tokenBucketScript = goredis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+if now > ts then
+	tokens = math.min(capacity, tokens + (now - ts) * rate)
+	ts = now
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], ttl)
+return {allowed, tostring(tokens)}
+`)
+
+// TokenBucket refills limit tokens per period into a bucket holding up to burst tokens; every
+// request takes one. It allows short bursts while enforcing the average rate.
+type TokenBucket struct {
+	redis    *redis.Client
+	breaker  breaker
+	memory   *memoryStore
+	name     string
+	capacity int
+	rate     float64 // tokens per millisecond
+}
+
+// NewTokenBucket creates a token bucket limiter. A burst of zero or less defaults to limit.
+// Buckets live in Redis when client is set; while Redis is unavailable each instance keeps
+// its own buckets in memory instead.
+func NewTokenBucket(client *redis.Client, name string, limit int, period time.Duration, burst int) *TokenBucket {
+	if burst <= 0 {
+		burst = limit
+	}
+
+	return &TokenBucket{
+		redis:    client,
+		memory:   newMemoryStore(),
+		name:     name,
+		capacity: burst,
+		rate:     float64(limit) / float64(period.Milliseconds()),
+	}
+}
+
+// Allow takes a token from key's bucket if one is available
+func (l *TokenBucket) Allow(ctx context.Context, key string) (*Result, error) {
+	key = storageKey(l.name, key)
+
+	if l.redis != nil && l.breaker.available() {
+		allowed, tokens, err := l.takeRedis(ctx, key)
+		if err == nil {
+			return l.result(allowed, tokens), nil
+		}
+		l.breaker.fail()
+	}
+
+	// Without Redis, keep the bucket locally rather than rejecting or admitting everything
+	allowed, tokens := l.memory.take(key, float64(l.capacity), l.rate, time.Now(), l.fillTime())
+	return l.result(allowed, tokens), nil
+}
+
+func (l *TokenBucket) takeRedis(ctx context.Context, key string) (bool, float64, error) {
+	reply, err := tokenBucketScript.Run(ctx, l.redis.Client, []string{key},
+		l.capacity, strconv.FormatFloat(l.rate, 'f', -1, 64), l.fillTime().Milliseconds(),
+	).Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(reply) != 2 {
+		return false, 0, fmt.Errorf("unexpected token bucket reply: %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	value, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false, 0, fmt.Errorf("unexpected token bucket reply: %w", err)
+	}
+
+	return allowed == 1, tokens, nil
+}
+
+func (l *TokenBucket) result(allowed bool, tokens float64) *Result {
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     l.capacity,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     l.refillTime(float64(l.capacity) - tokens),
+	}
+	if !allowed {
+		result.RetryAfter = l.refillTime(1 - tokens)
+	}
+	return result
+}
+
+// fillTime is how long an empty bucket takes to fill up; idle buckets expire after it
+func (l *TokenBucket) fillTime() time.Duration {
+	fill := l.refillTime(float64(l.capacity))
+	if fill < time.Second {
+		fill = time.Second
+	}
+	return fill
+}
+
+// refillTime is how long it takes to refill the given number of tokens
+func (l *TokenBucket) refillTime(tokens float64) time.Duration {
+	if tokens <= 0 || l.rate <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens/l.rate)) * time.Millisecond
+}
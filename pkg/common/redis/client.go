@@ -7,6 +7,9 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
+// Nil is returned by reads when the key does not exist
+const Nil = redis.Nil
+
 // Client wraps redis client with additional functionality
 type Client struct {
 	*redis.Client
@@ -49,6 +52,11 @@ func (c *Client) GetString(ctx context.Context, key string) (string, error) {
 	return c.Client.Get(ctx, key).Result()
 }
 
+// GetDelete gets a string value and deletes the key in one step, for single-use values
+func (c *Client) GetDelete(ctx context.Context, key string) (string, error) {
+	return c.Client.GetDel(ctx, key).Result()
+}
+
 // Delete deletes a key
 func (c *Client) Delete(ctx context.Context, keys ...string) error {
 	return c.Client.Del(ctx, keys...).Err()
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/VariableSan/go-factory-microservice/pkg/common/errors"
+	commonpb "github.com/VariableSan/go-factory-microservice/pkg/proto/common"
 )
 
 // APIResponse represents a standard API response
@@ -20,6 +21,8 @@ type ErrorInfo struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	// Fields lists what is wrong with individual fields of the request
+	Fields []*commonpb.ErrorDetail `json:"fields,omitempty"`
 }
 
 // Success sends a successful response
@@ -93,6 +96,19 @@ func BadRequest(w http.ResponseWriter, message string) {
 	sendJSON(w, http.StatusBadRequest, response)
 }
 
+// ValidationError sends a bad request error listing what is wrong with each field
+func ValidationError(w http.ResponseWriter, message string, fields []*commonpb.ErrorDetail) {
+	response := APIResponse{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    string(errors.ErrValidation),
+			Message: message,
+			Fields:  fields,
+		},
+	}
+	sendJSON(w, http.StatusBadRequest, response)
+}
+
 // Unauthorized sends an unauthorized error
 func Unauthorized(w http.ResponseWriter, message string) {
 	response := APIResponse{
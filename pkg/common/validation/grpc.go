@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// UnaryServerInterceptor is the gRPC counterpart of DecodeJSON. Requests to the methods listed in
+// rules, keyed by full method name, are validated with Message before the handler runs. Invalid
+// requests fail with InvalidArgument, carrying one common.v1.ErrorDetail per invalid field as
+// status details.
+func UnaryServerInterceptor(rules map[string]Rules) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		methodRules, ok := rules[info.FullMethod]
+		msg, isMessage := req.(proto.Message)
+		if !ok || !isMessage {
+			return handler(ctx, req)
+		}
+
+		err := Message(msg, methodRules)
+		var validationErr *Error
+		if errors.As(err, &validationErr) {
+			st := status.New(codes.InvalidArgument, validationErr.Error())
+			details := make([]protoadapt.MessageV1, 0, len(validationErr.Fields))
+			for _, field := range validationErr.Fields {
+				details = append(details, protoadapt.MessageV1Of(field))
+			}
+			if withDetails, detailsErr := st.WithDetails(details...); detailsErr == nil {
+				st = withDetails
+			}
+			return nil, st.Err()
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to validate request")
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	commonpb "github.com/VariableSan/go-factory-microservice/pkg/proto/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const method = "/common.v1.Test/List"
+	interceptor := UnaryServerInterceptor(map[string]Rules{
+		method:                {"page": "min=1", "page_size": "max=100"},
+		"/common.v1.Test/Bad": {"email": "required"},
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		req         interface{}
+		wantCode    codes.Code
+		wantDetails int
+		wantHandled bool
+	}{
+		{name: "valid", method: method, req: &commonpb.PaginationRequest{Page: 1}, wantCode: codes.OK, wantHandled: true},
+		{name: "invalid", method: method, req: &commonpb.PaginationRequest{PageSize: 101}, wantCode: codes.InvalidArgument, wantDetails: 2},
+		{name: "method without rules", method: "/common.v1.Test/Other", req: &commonpb.PaginationRequest{}, wantCode: codes.OK, wantHandled: true},
+		{name: "not a protobuf message", method: method, req: "request", wantCode: codes.OK, wantHandled: true},
+		{name: "rules name an unknown field", method: "/common.v1.Test/Bad", req: &commonpb.PaginationRequest{}, wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handled = true
+				return req, nil
+			}
+
+			_, err := interceptor(context.Background(), tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", st.Code(), tt.wantCode, err)
+			}
+			if len(st.Details()) != tt.wantDetails {
+				t.Errorf("details = %v, want %d", st.Details(), tt.wantDetails)
+			}
+			for _, detail := range st.Details() {
+				if _, ok := detail.(*commonpb.ErrorDetail); !ok {
+					t.Errorf("detail %T is not a common.v1.ErrorDetail", detail)
+				}
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("handler called = %v, want %v", handled, tt.wantHandled)
+			}
+		})
+	}
+}
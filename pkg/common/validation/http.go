@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	commonErrors "github.com/VariableSan/go-factory-microservice/pkg/common/errors"
+	"github.com/VariableSan/go-factory-microservice/pkg/common/response"
+)
+
+// MaxBodySize is the largest request body DecodeJSON reads
+const MaxBodySize = 1 << 20
+
+var (
+	ErrInvalidBody  = errors.New("invalid request body")
+	ErrBodyTooLarge = fmt.Errorf("request body exceeds %d bytes", MaxBodySize)
+)
+
+// DecodeJSON decodes the JSON body of r into dst, a pointer to a struct, and validates it with
+// Struct. Bodies larger than MaxBodySize, unknown fields and data after the JSON value are
+// rejected. Pass the error to WriteError to answer the request.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ErrBodyTooLarge
+		}
+		return fmt.Errorf("%w: %v", ErrInvalidBody, err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("%w: unexpected data after the JSON value", ErrInvalidBody)
+	}
+
+	return Struct(dst)
+}
+
+// WriteError answers a request whose body DecodeJSON rejected: invalid fields are listed with
+// response.ValidationError, oversized bodies get 413 and malformed ones 400
+func WriteError(w http.ResponseWriter, err error) {
+	var validationErr *Error
+	switch {
+	case errors.As(err, &validationErr):
+		response.ValidationError(w, "Request validation failed", validationErr.Fields)
+	case errors.Is(err, ErrBodyTooLarge):
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrPayloadTooLarge, "Request body too large"))
+	case errors.Is(err, ErrInvalidBody):
+		response.BadRequest(w, "Invalid request body")
+	default:
+		response.InternalError(w, "Failed to validate request")
+	}
+}
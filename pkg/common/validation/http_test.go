@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantErr    error
+		wantFields bool
+	}{
+		{name: "valid", body: `{"email": "jane@example.com", "name": "Jane"}`},
+		{name: "trailing whitespace", body: "{\"email\": \"jane@example.com\", \"name\": \"Jane\"}\n"},
+		{name: "invalid fields", body: `{"email": "jane", "name": "Jane"}`, wantFields: true},
+		{name: "empty body", body: ``, wantErr: ErrInvalidBody},
+		{name: "malformed json", body: `{"email": `, wantErr: ErrInvalidBody},
+		{name: "wrong type", body: `{"email": 42}`, wantErr: ErrInvalidBody},
+		{name: "unknown field", body: `{"email": "jane@example.com", "name": "Jane", "admin": true}`, wantErr: ErrInvalidBody},
+		{name: "second value", body: `{"email": "jane@example.com", "name": "Jane"} {}`, wantErr: ErrInvalidBody},
+		{name: "too large", body: `{"name": "` + strings.Repeat("a", MaxBodySize) + `"}`, wantErr: ErrBodyTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			var dst signup
+			err := DecodeJSON(httptest.NewRecorder(), r, &dst)
+
+			var validationErr *Error
+			switch {
+			case tt.wantFields:
+				if !errors.As(err, &validationErr) {
+					t.Errorf("DecodeJSON() error = %v, want an *Error", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("DecodeJSON() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Errorf("DecodeJSON() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "invalid fields", err: &Error{Fields: nil}, wantStatus: http.StatusBadRequest, wantCode: "VALIDATION_ERROR"},
+		{name: "too large", err: ErrBodyTooLarge, wantStatus: http.StatusRequestEntityTooLarge, wantCode: "PAYLOAD_TOO_LARGE"},
+		{name: "malformed", err: ErrInvalidBody, wantStatus: http.StatusBadRequest},
+		{name: "other", err: errors.New("validation: unknown rule"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteError(w, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var body struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			if body.Error.Code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", body.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
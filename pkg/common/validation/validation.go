@@ -0,0 +1,337 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	commonpb "github.com/VariableSan/go-factory-microservice/pkg/proto/common"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rules that can appear in a validate tag, separated by commas. They are also the codes of the
+// field errors they report.
+const (
+	// RuleRequired rejects zero values and strings that are only whitespace
+	RuleRequired = "required"
+	// RuleOmitEmpty skips the remaining rules for zero values
+	RuleOmitEmpty = "omitempty"
+	// RuleEmail requires a single address without a display name, and normalizes it with NormalizeEmail
+	RuleEmail = "email"
+	// RuleMin and RuleMax bound the number of characters of strings, the length of slices and
+	// maps, and the value of numbers, as in "min=3"
+	RuleMin = "min"
+	RuleMax = "max"
+	// RuleOneOf lists the allowed values of a string, separated by spaces, as in "oneof=zip json"
+	RuleOneOf = "oneof"
+)
+
+// Error lists every field of a request that failed validation
+type Error struct {
+	Fields []*commonpb.ErrorDetail
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+" "+field.Message)
+	}
+	return "invalid request: " + strings.Join(messages, "; ")
+}
+
+// Rules maps protobuf field names to validate tags, for messages that cannot carry struct tags
+type Rules map[string]string
+
+// NormalizeEmail trims whitespace around an email address and lower-cases its domain, which is
+// case-insensitive. The local part is kept as is, as mail servers may treat it case-sensitively.
+func NormalizeEmail(email string) string {
+	email = strings.TrimSpace(email)
+	if i := strings.LastIndex(email, "@"); i >= 0 {
+		return email[:i] + strings.ToLower(email[i:])
+	}
+	return email
+}
+
+// Struct validates the fields of the struct v points to against their validate tags, naming
+// them by their JSON names. Nested structs are validated too, with their fields named
+// "parent.child". Email fields are normalized in place. Returns an *Error listing every invalid
+// field; other errors mean a tag could not be parsed.
+func Struct(v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("validation: %T is not a pointer to a struct", v)
+	}
+
+	var fields []*commonpb.ErrorDetail
+	if err := validateStruct(value.Elem(), "", &fields); err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return &Error{Fields: fields}
+	}
+	return nil
+}
+
+func validateStruct(value reflect.Value, prefix string, fields *[]*commonpb.ErrorDetail) error {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		name = prefix + name
+
+		fieldValue := value.Field(i)
+		if tag := field.Tag.Get("validate"); tag != "" {
+			rules, err := parseRules(tag)
+			if err != nil {
+				return fmt.Errorf("validation: field %s: %w", name, err)
+			}
+			if detail := checkValue(name, fieldValue, rules); detail != nil {
+				*fields = append(*fields, detail)
+				continue
+			}
+		}
+
+		for fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() == reflect.Struct {
+			if err := validateStruct(fieldValue, name+".", fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonName is the name encoding/json gives a struct field
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// checkValue applies rules to a struct field, normalizing it if it is an email address
+func checkValue(name string, value reflect.Value, rules []rule) *commonpb.ErrorDetail {
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	zero := value.IsZero()
+	if value.Kind() == reflect.String {
+		zero = strings.TrimSpace(value.String()) == ""
+	}
+
+	for _, r := range rules {
+		if r.name == RuleEmail && value.Kind() == reflect.String && value.CanSet() {
+			value.SetString(NormalizeEmail(value.String()))
+		}
+	}
+
+	var size func() (float64, bool)
+	switch value.Kind() {
+	case reflect.String:
+		size = func() (float64, bool) { return float64(utf8.RuneCountInString(value.String())), true }
+	case reflect.Slice, reflect.Map, reflect.Array:
+		size = func() (float64, bool) { return float64(value.Len()), true }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = func() (float64, bool) { return float64(value.Int()), true }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = func() (float64, bool) { return float64(value.Uint()), true }
+	case reflect.Float32, reflect.Float64:
+		size = func() (float64, bool) { return value.Float(), true }
+	default:
+		size = func() (float64, bool) { return 0, false }
+	}
+
+	str := ""
+	if value.Kind() == reflect.String {
+		str = value.String()
+	}
+	return check(name, zero, str, value.Kind() == reflect.String, size, rules)
+}
+
+// Message validates a protobuf message against rules keyed by field name. Singular string fields
+// with the email rule are normalized in place. Returns an *Error listing every invalid field;
+// other errors mean the rules name an unknown field or cannot be parsed.
+func Message(msg proto.Message, rules Rules) error {
+	m := msg.ProtoReflect()
+	descriptor := m.Descriptor()
+
+	fds := make([]protoreflect.FieldDescriptor, 0, len(rules))
+	for name := range rules {
+		fd := descriptor.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("validation: %s has no field %q", descriptor.FullName(), name)
+		}
+		fds = append(fds, fd)
+	}
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Number() < fds[j].Number() })
+
+	var fields []*commonpb.ErrorDetail
+	for _, fd := range fds {
+		fieldRules, err := parseRules(rules[string(fd.Name())])
+		if err != nil {
+			return fmt.Errorf("validation: field %s: %w", fd.Name(), err)
+		}
+		if detail := checkField(m, fd, fieldRules); detail != nil {
+			fields = append(fields, detail)
+		}
+	}
+
+	if len(fields) > 0 {
+		return &Error{Fields: fields}
+	}
+	return nil
+}
+
+// checkField applies rules to a field of a protobuf message, normalizing it if it is an email address
+func checkField(m protoreflect.Message, fd protoreflect.FieldDescriptor, rules []rule) *commonpb.ErrorDetail {
+	name := string(fd.Name())
+	value := m.Get(fd)
+	isString := fd.Kind() == protoreflect.StringKind && fd.Cardinality() != protoreflect.Repeated
+
+	str := ""
+	if isString {
+		str = value.String()
+		for _, r := range rules {
+			if r.name == RuleEmail && m.Has(fd) {
+				str = NormalizeEmail(str)
+				m.Set(fd, protoreflect.ValueOfString(str))
+			}
+		}
+	}
+
+	zero := !m.Has(fd)
+	if isString {
+		zero = strings.TrimSpace(str) == ""
+	}
+
+	size := func() (float64, bool) {
+		switch {
+		case fd.IsList():
+			return float64(value.List().Len()), true
+		case fd.IsMap():
+			return float64(value.Map().Len()), true
+		case isString:
+			return float64(utf8.RuneCountInString(str)), true
+		}
+		switch fd.Kind() {
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+			protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			return float64(value.Int()), true
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return float64(value.Uint()), true
+		case protoreflect.FloatKind, protoreflect.DoubleKind:
+			return value.Float(), true
+		}
+		return 0, false
+	}
+
+	return check(name, zero, str, isString, size, rules)
+}
+
+// check applies rules to a field, returning the first rule it breaks. str is the value of string
+// fields; size returns the length or value the min and max rules compare, if the field has one.
+func check(name string, zero bool, str string, isString bool, size func() (float64, bool), rules []rule) *commonpb.ErrorDetail {
+	for _, r := range rules {
+		switch r.name {
+		case RuleRequired:
+			if zero {
+				return fieldError(name, r.name, "is required")
+			}
+		case RuleOmitEmpty:
+			if zero {
+				return nil
+			}
+		case RuleEmail:
+			if isString && !zero && !validEmail(str) {
+				return fieldError(name, r.name, "must be a valid email address")
+			}
+		case RuleMin, RuleMax:
+			n, ok := size()
+			if !ok {
+				continue
+			}
+			bound, _ := strconv.ParseFloat(r.param, 64)
+			if r.name == RuleMin && n < bound {
+				return fieldError(name, r.name, "must be at least "+boundText(r.param, isString))
+			}
+			if r.name == RuleMax && n > bound {
+				return fieldError(name, r.name, "must be at most "+boundText(r.param, isString))
+			}
+		case RuleOneOf:
+			if isString && !zero && !contains(strings.Fields(r.param), str) {
+				return fieldError(name, r.name, "must be one of "+strings.Join(strings.Fields(r.param), ", "))
+			}
+		}
+	}
+	return nil
+}
+
+func fieldError(field, code, message string) *commonpb.ErrorDetail {
+	return &commonpb.ErrorDetail{Code: code, Message: message, Field: field}
+}
+
+func boundText(param string, isString bool) string {
+	if isString {
+		return param + " characters long"
+	}
+	return param
+}
+
+// validEmail accepts a bare address such as "jane@example.com", but not "Jane <jane@example.com>"
+func validEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email && address.Name == ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// rule is one entry of a validate tag, such as "min=8"
+type rule struct {
+	name  string
+	param string
+}
+
+func parseRules(tag string) ([]rule, error) {
+	var rules []rule
+	for _, entry := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		switch name {
+		case RuleRequired, RuleOmitEmpty, RuleEmail:
+		case RuleMin, RuleMax:
+			if _, err := strconv.ParseFloat(param, 64); err != nil {
+				return nil, fmt.Errorf("rule %q needs a number", name)
+			}
+		case RuleOneOf:
+			if strings.TrimSpace(param) == "" {
+				return nil, fmt.Errorf("rule %q needs values", name)
+			}
+		default:
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules, nil
+}
@@ -0,0 +1,223 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	commonpb "github.com/VariableSan/go-factory-microservice/pkg/proto/common"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type signup struct {
+	Email    string   `json:"email" validate:"required,email"`
+	Name     string   `json:"name" validate:"required,max=5"`
+	Nickname *string  `json:"nickname,omitempty" validate:"omitempty,min=3"`
+	Age      int      `json:"age" validate:"omitempty,min=18,max=130"`
+	Format   string   `json:"format" validate:"omitempty,oneof=zip json"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Address  *address `json:"address"`
+	Ignored  string   `json:"-" validate:"required"`
+	internal string
+}
+
+// fieldCodes lists "field:code" for every field error of err
+func fieldCodes(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var validationErr *Error
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want an *Error", err)
+	}
+	codes := make([]string, 0, len(validationErr.Fields))
+	for _, field := range validationErr.Fields {
+		codes = append(codes, field.Field+":"+field.Code)
+	}
+	return codes
+}
+
+func TestStruct(t *testing.T) {
+	short, long := "ab", "abcd"
+
+	tests := []struct {
+		name  string
+		input signup
+		want  []string
+	}{
+		{name: "valid", input: signup{Email: "jane@example.com", Name: "Jane"}},
+		{name: "missing required fields", input: signup{}, want: []string{"email:required", "name:required"}},
+		{name: "whitespace is missing", input: signup{Email: "jane@example.com", Name: "  "}, want: []string{"name:required"}},
+		{name: "invalid email", input: signup{Email: "jane", Name: "Jane"}, want: []string{"email:email"}},
+		{name: "email with display name", input: signup{Email: "Jane <jane@example.com>", Name: "Jane"}, want: []string{"email:email"}},
+		{name: "max counts characters", input: signup{Email: "jane@example.com", Name: "Jürgen"}, want: []string{"name:max"}},
+		{name: "at max characters", input: signup{Email: "jane@example.com", Name: "Jürge"}},
+		{name: "nil pointer is omitted", input: signup{Email: "jane@example.com", Name: "Jane", Nickname: nil}},
+		{name: "pointer too short", input: signup{Email: "jane@example.com", Name: "Jane", Nickname: &short}, want: []string{"nickname:min"}},
+		{name: "pointer long enough", input: signup{Email: "jane@example.com", Name: "Jane", Nickname: &long}},
+		{name: "number below min", input: signup{Email: "jane@example.com", Name: "Jane", Age: 17}, want: []string{"age:min"}},
+		{name: "number at min", input: signup{Email: "jane@example.com", Name: "Jane", Age: 18}},
+		{name: "number above max", input: signup{Email: "jane@example.com", Name: "Jane", Age: 131}, want: []string{"age:max"}},
+		{name: "oneof", input: signup{Email: "jane@example.com", Name: "Jane", Format: "json"}},
+		{name: "not oneof", input: signup{Email: "jane@example.com", Name: "Jane", Format: "xml"}, want: []string{"format:oneof"}},
+		{name: "too many items", input: signup{Email: "jane@example.com", Name: "Jane", Tags: []string{"a", "b", "c"}}, want: []string{"tags:max"}},
+		{name: "nested struct", input: signup{Email: "jane@example.com", Name: "Jane", Address: &address{}}, want: []string{"address.city:required"}},
+		{name: "every field is reported", input: signup{Email: "jane", Name: "Jürgen", Age: 1}, want: []string{"email:email", "name:max", "age:min"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			if got := fieldCodes(t, Struct(&input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructNormalizesEmail(t *testing.T) {
+	input := signup{Email: "  Jane.Doe@Example.COM ", Name: "Jane"}
+	if err := Struct(&input); err != nil {
+		t.Fatalf("Struct() error = %v", err)
+	}
+	if input.Email != "Jane.Doe@example.com" {
+		t.Errorf("Email = %q, want %q", input.Email, "Jane.Doe@example.com")
+	}
+}
+
+func TestStructInvalidInput(t *testing.T) {
+	type unknownRule struct {
+		Name string `validate:"uppercase"`
+	}
+	type missingBound struct {
+		Name string `validate:"min=x"`
+	}
+	type missingValues struct {
+		Name string `validate:"oneof="`
+	}
+
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{name: "not a pointer", input: signup{}},
+		{name: "nil pointer", input: (*signup)(nil)},
+		{name: "pointer to a non-struct", input: new(string)},
+		{name: "unknown rule", input: &unknownRule{}},
+		{name: "bound is not a number", input: &missingBound{}},
+		{name: "oneof without values", input: &missingValues{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.input)
+			var validationErr *Error
+			if err == nil || errors.As(err, &validationErr) {
+				t.Errorf("Struct() error = %v, want a usage error", err)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   func() *commonpb.PaginationRequest
+		rules Rules
+		want  []string
+	}{
+		{
+			name:  "valid",
+			msg:   func() *commonpb.PaginationRequest { return &commonpb.PaginationRequest{Page: 1, PageSize: 20} },
+			rules: Rules{"page": "min=1", "page_size": "min=1,max=100"},
+		},
+		{
+			name:  "numbers out of range",
+			msg:   func() *commonpb.PaginationRequest { return &commonpb.PaginationRequest{Page: 0, PageSize: 101} },
+			rules: Rules{"page_size": "min=1,max=100", "page": "min=1"},
+			want:  []string{"page:min", "page_size:max"},
+		},
+		{
+			name:  "unset field is missing",
+			msg:   func() *commonpb.PaginationRequest { return &commonpb.PaginationRequest{} },
+			rules: Rules{"sort_by": "required", "page": "required"},
+			want:  []string{"page:required", "sort_by:required"},
+		},
+		{
+			name:  "omitempty skips unset fields",
+			msg:   func() *commonpb.PaginationRequest { return &commonpb.PaginationRequest{} },
+			rules: Rules{"page": "omitempty,min=1", "sort_order": "omitempty,oneof=asc desc"},
+		},
+		{
+			name:  "oneof",
+			msg:   func() *commonpb.PaginationRequest { return &commonpb.PaginationRequest{SortOrder: "up"} },
+			rules: Rules{"sort_order": "omitempty,oneof=asc desc"},
+			want:  []string{"sort_order:oneof"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldCodes(t, Message(tt.msg(), tt.rules)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Message() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageRepeatedAndEmail(t *testing.T) {
+	filter := &commonpb.Filter{Field: "  Jane@Example.COM", Values: []string{"a", "b", "c"}}
+
+	got := fieldCodes(t, Message(filter, Rules{"field": "required,email", "values": "min=1,max=2"}))
+	if want := []string{"values:max"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Message() fields = %v, want %v", got, want)
+	}
+	if filter.Field != "Jane@example.com" {
+		t.Errorf("Field = %q, want the normalized address", filter.Field)
+	}
+}
+
+func TestMessageInvalidRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules Rules
+	}{
+		{name: "unknown field", rules: Rules{"email": "required"}},
+		{name: "unknown rule", rules: Rules{"page": "positive"}},
+		{name: "bound is not a number", rules: Rules{"page": "max=many"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Message(&commonpb.PaginationRequest{}, tt.rules)
+			var validationErr *Error
+			if err == nil || errors.As(err, &validationErr) {
+				t.Errorf("Message() error = %v, want a usage error", err)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{email: "jane@example.com", want: "jane@example.com"},
+		{email: " Jane@EXAMPLE.com\t", want: "Jane@example.com"},
+		{email: "\"a@b\"@Example.com", want: "\"a@b\"@example.com"},
+		{email: "jane", want: "jane"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			if got := NormalizeEmail(tt.email); got != tt.want {
+				t.Errorf("NormalizeEmail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
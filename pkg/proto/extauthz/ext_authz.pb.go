@@ -0,0 +1,898 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: extauthz/ext_authz.proto
+
+// Wire-compatible subset of Envoy's external authorization API (envoy/service/auth/v3/external_auth.proto).
+// Nested Envoy messages are flattened and fields the auth service does not use are left out; unknown
+// fields sent by Envoy are skipped on decoding.
+
+package extauthz
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HTTP status codes used by the auth service (envoy.type.v3.StatusCode)
+type StatusCode int32
+
+const (
+	StatusCode_Empty               StatusCode = 0
+	StatusCode_OK                  StatusCode = 200
+	StatusCode_BadRequest          StatusCode = 400
+	StatusCode_Unauthorized        StatusCode = 401
+	StatusCode_Forbidden           StatusCode = 403
+	StatusCode_InternalServerError StatusCode = 500
+	StatusCode_ServiceUnavailable  StatusCode = 503
+)
+
+// Enum value maps for StatusCode.
+var (
+	StatusCode_name = map[int32]string{
+		0:   "Empty",
+		200: "OK",
+		400: "BadRequest",
+		401: "Unauthorized",
+		403: "Forbidden",
+		500: "InternalServerError",
+		503: "ServiceUnavailable",
+	}
+	StatusCode_value = map[string]int32{
+		"Empty":               0,
+		"OK":                  200,
+		"BadRequest":          400,
+		"Unauthorized":        401,
+		"Forbidden":           403,
+		"InternalServerError": 500,
+		"ServiceUnavailable":  503,
+	}
+)
+
+func (x StatusCode) Enum() *StatusCode {
+	p := new(StatusCode)
+	*p = x
+	return p
+}
+
+func (x StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_extauthz_ext_authz_proto_enumTypes[0].Descriptor()
+}
+
+func (StatusCode) Type() protoreflect.EnumType {
+	return &file_extauthz_ext_authz_proto_enumTypes[0]
+}
+
+func (x StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatusCode.Descriptor instead.
+func (StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{0}
+}
+
+// How a header is combined with existing values (HeaderValueOption.HeaderAppendAction)
+type HeaderAppendAction int32
+
+const (
+	HeaderAppendAction_APPEND_IF_EXISTS_OR_ADD    HeaderAppendAction = 0
+	HeaderAppendAction_ADD_IF_ABSENT              HeaderAppendAction = 1
+	HeaderAppendAction_OVERWRITE_IF_EXISTS_OR_ADD HeaderAppendAction = 2
+	HeaderAppendAction_OVERWRITE_IF_EXISTS        HeaderAppendAction = 3
+)
+
+// Enum value maps for HeaderAppendAction.
+var (
+	HeaderAppendAction_name = map[int32]string{
+		0: "APPEND_IF_EXISTS_OR_ADD",
+		1: "ADD_IF_ABSENT",
+		2: "OVERWRITE_IF_EXISTS_OR_ADD",
+		3: "OVERWRITE_IF_EXISTS",
+	}
+	HeaderAppendAction_value = map[string]int32{
+		"APPEND_IF_EXISTS_OR_ADD":    0,
+		"ADD_IF_ABSENT":              1,
+		"OVERWRITE_IF_EXISTS_OR_ADD": 2,
+		"OVERWRITE_IF_EXISTS":        3,
+	}
+)
+
+func (x HeaderAppendAction) Enum() *HeaderAppendAction {
+	p := new(HeaderAppendAction)
+	*p = x
+	return p
+}
+
+func (x HeaderAppendAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HeaderAppendAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_extauthz_ext_authz_proto_enumTypes[1].Descriptor()
+}
+
+func (HeaderAppendAction) Type() protoreflect.EnumType {
+	return &file_extauthz_ext_authz_proto_enumTypes[1]
+}
+
+func (x HeaderAppendAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HeaderAppendAction.Descriptor instead.
+func (HeaderAppendAction) EnumDescriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{1}
+}
+
+// Check request
+type CheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attributes    *AttributeContext      `protobuf:"bytes,1,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckRequest) GetAttributes() *AttributeContext {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// Attributes of the request being checked (envoy.service.auth.v3.AttributeContext)
+type AttributeContext struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Request           *Request               `protobuf:"bytes,4,opt,name=request,proto3" json:"request,omitempty"`
+	ContextExtensions map[string]string      `protobuf:"bytes,10,rep,name=context_extensions,json=contextExtensions,proto3" json:"context_extensions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Set per route in the Envoy configuration
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AttributeContext) Reset() {
+	*x = AttributeContext{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeContext) ProtoMessage() {}
+
+func (x *AttributeContext) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeContext.ProtoReflect.Descriptor instead.
+func (*AttributeContext) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AttributeContext) GetRequest() *Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *AttributeContext) GetContextExtensions() map[string]string {
+	if x != nil {
+		return x.ContextExtensions
+	}
+	return nil
+}
+
+// Request attributes (AttributeContext.Request)
+type Request struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Http          *HttpRequest           `protobuf:"bytes,2,opt,name=http,proto3" json:"http,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Request) Reset() {
+	*x = Request{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request) ProtoMessage() {}
+
+func (x *Request) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request.ProtoReflect.Descriptor instead.
+func (*Request) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Request) GetHttp() *HttpRequest {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+// HTTP request attributes (AttributeContext.HttpRequest)
+type HttpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Header names are lower-cased
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`                                                                                 // Includes the query string
+	Host          string                 `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`
+	Scheme        string                 `protobuf:"bytes,6,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpRequest) Reset() {
+	*x = HttpRequest{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpRequest) ProtoMessage() {}
+
+func (x *HttpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpRequest.ProtoReflect.Descriptor instead.
+func (*HttpRequest) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HttpRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HttpRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HttpRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HttpRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HttpRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *HttpRequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+// Check response
+type CheckResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         *Status                `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	DeniedResponse *DeniedHttpResponse    `protobuf:"bytes,2,opt,name=denied_response,json=deniedResponse,proto3" json:"denied_response,omitempty"` // Set when the request is denied
+	OkResponse     *OkHttpResponse        `protobuf:"bytes,3,opt,name=ok_response,json=okResponse,proto3" json:"ok_response,omitempty"`             // Set when the request is allowed
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckResponse) Reset() {
+	*x = CheckResponse{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResponse) ProtoMessage() {}
+
+func (x *CheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResponse.ProtoReflect.Descriptor instead.
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *CheckResponse) GetDeniedResponse() *DeniedHttpResponse {
+	if x != nil {
+		return x.DeniedResponse
+	}
+	return nil
+}
+
+func (x *CheckResponse) GetOkResponse() *OkHttpResponse {
+	if x != nil {
+		return x.OkResponse
+	}
+	return nil
+}
+
+// Result of the check (google.rpc.Status)
+type Status struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Status) Reset() {
+	*x = Status{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Status) ProtoMessage() {}
+
+func (x *Status) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Status) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Status) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Response sent to the client when a request is denied
+type DeniedHttpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *HttpStatus            `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Headers       []*HeaderValueOption   `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeniedHttpResponse) Reset() {
+	*x = DeniedHttpResponse{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeniedHttpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeniedHttpResponse) ProtoMessage() {}
+
+func (x *DeniedHttpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeniedHttpResponse.ProtoReflect.Descriptor instead.
+func (*DeniedHttpResponse) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeniedHttpResponse) GetStatus() *HttpStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *DeniedHttpResponse) GetHeaders() []*HeaderValueOption {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *DeniedHttpResponse) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// Changes applied to the request before it is forwarded upstream
+type OkHttpResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Headers         []*HeaderValueOption   `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	HeadersToRemove []string               `protobuf:"bytes,5,rep,name=headers_to_remove,json=headersToRemove,proto3" json:"headers_to_remove,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *OkHttpResponse) Reset() {
+	*x = OkHttpResponse{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OkHttpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OkHttpResponse) ProtoMessage() {}
+
+func (x *OkHttpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OkHttpResponse.ProtoReflect.Descriptor instead.
+func (*OkHttpResponse) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OkHttpResponse) GetHeaders() []*HeaderValueOption {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *OkHttpResponse) GetHeadersToRemove() []string {
+	if x != nil {
+		return x.HeadersToRemove
+	}
+	return nil
+}
+
+// HTTP status of a denied response (envoy.type.v3.HttpStatus)
+type HttpStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          StatusCode             `protobuf:"varint,1,opt,name=code,proto3,enum=envoy.service.auth.v3.StatusCode" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpStatus) Reset() {
+	*x = HttpStatus{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpStatus) ProtoMessage() {}
+
+func (x *HttpStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpStatus.ProtoReflect.Descriptor instead.
+func (*HttpStatus) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HttpStatus) GetCode() StatusCode {
+	if x != nil {
+		return x.Code
+	}
+	return StatusCode_Empty
+}
+
+// Header to add to a request or response (envoy.config.core.v3.HeaderValueOption)
+type HeaderValueOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *HeaderValue           `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	AppendAction  HeaderAppendAction     `protobuf:"varint,3,opt,name=append_action,json=appendAction,proto3,enum=envoy.service.auth.v3.HeaderAppendAction" json:"append_action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeaderValueOption) Reset() {
+	*x = HeaderValueOption{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeaderValueOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderValueOption) ProtoMessage() {}
+
+func (x *HeaderValueOption) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderValueOption.ProtoReflect.Descriptor instead.
+func (*HeaderValueOption) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HeaderValueOption) GetHeader() *HeaderValue {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *HeaderValueOption) GetAppendAction() HeaderAppendAction {
+	if x != nil {
+		return x.AppendAction
+	}
+	return HeaderAppendAction_APPEND_IF_EXISTS_OR_ADD
+}
+
+// Header name and value (envoy.config.core.v3.HeaderValue)
+type HeaderValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeaderValue) Reset() {
+	*x = HeaderValue{}
+	mi := &file_extauthz_ext_authz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeaderValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderValue) ProtoMessage() {}
+
+func (x *HeaderValue) ProtoReflect() protoreflect.Message {
+	mi := &file_extauthz_ext_authz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderValue.ProtoReflect.Descriptor instead.
+func (*HeaderValue) Descriptor() ([]byte, []int) {
+	return file_extauthz_ext_authz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HeaderValue) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HeaderValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_extauthz_ext_authz_proto protoreflect.FileDescriptor
+
+const file_extauthz_ext_authz_proto_rawDesc = "" +
+	"\n" +
+	"\x18extauthz/ext_authz.proto\x12\x15envoy.service.auth.v3\"W\n" +
+	"\fCheckRequest\x12G\n" +
+	"\n" +
+	"attributes\x18\x01 \x01(\v2'.envoy.service.auth.v3.AttributeContextR\n" +
+	"attributes\"\x81\x02\n" +
+	"\x10AttributeContext\x128\n" +
+	"\arequest\x18\x04 \x01(\v2\x1e.envoy.service.auth.v3.RequestR\arequest\x12m\n" +
+	"\x12context_extensions\x18\n" +
+	" \x03(\v2>.envoy.service.auth.v3.AttributeContext.ContextExtensionsEntryR\x11contextExtensions\x1aD\n" +
+	"\x16ContextExtensionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
+	"\aRequest\x126\n" +
+	"\x04http\x18\x02 \x01(\v2\".envoy.service.auth.v3.HttpRequestR\x04http\"\xfc\x01\n" +
+	"\vHttpRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12I\n" +
+	"\aheaders\x18\x03 \x03(\v2/.envoy.service.auth.v3.HttpRequest.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x12\n" +
+	"\x04host\x18\x05 \x01(\tR\x04host\x12\x16\n" +
+	"\x06scheme\x18\x06 \x01(\tR\x06scheme\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe2\x01\n" +
+	"\rCheckResponse\x125\n" +
+	"\x06status\x18\x01 \x01(\v2\x1d.envoy.service.auth.v3.StatusR\x06status\x12R\n" +
+	"\x0fdenied_response\x18\x02 \x01(\v2).envoy.service.auth.v3.DeniedHttpResponseR\x0edeniedResponse\x12F\n" +
+	"\vok_response\x18\x03 \x01(\v2%.envoy.service.auth.v3.OkHttpResponseR\n" +
+	"okResponse\"6\n" +
+	"\x06Status\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa7\x01\n" +
+	"\x12DeniedHttpResponse\x129\n" +
+	"\x06status\x18\x01 \x01(\v2!.envoy.service.auth.v3.HttpStatusR\x06status\x12B\n" +
+	"\aheaders\x18\x02 \x03(\v2(.envoy.service.auth.v3.HeaderValueOptionR\aheaders\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"\x80\x01\n" +
+	"\x0eOkHttpResponse\x12B\n" +
+	"\aheaders\x18\x02 \x03(\v2(.envoy.service.auth.v3.HeaderValueOptionR\aheaders\x12*\n" +
+	"\x11headers_to_remove\x18\x05 \x03(\tR\x0fheadersToRemove\"C\n" +
+	"\n" +
+	"HttpStatus\x125\n" +
+	"\x04code\x18\x01 \x01(\x0e2!.envoy.service.auth.v3.StatusCodeR\x04code\"\x9f\x01\n" +
+	"\x11HeaderValueOption\x12:\n" +
+	"\x06header\x18\x01 \x01(\v2\".envoy.service.auth.v3.HeaderValueR\x06header\x12N\n" +
+	"\rappend_action\x18\x03 \x01(\x0e2).envoy.service.auth.v3.HeaderAppendActionR\fappendAction\"5\n" +
+	"\vHeaderValue\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value*\x87\x01\n" +
+	"\n" +
+	"StatusCode\x12\t\n" +
+	"\x05Empty\x10\x00\x12\a\n" +
+	"\x02OK\x10\xc8\x01\x12\x0f\n" +
+	"\n" +
+	"BadRequest\x10\x90\x03\x12\x11\n" +
+	"\fUnauthorized\x10\x91\x03\x12\x0e\n" +
+	"\tForbidden\x10\x93\x03\x12\x18\n" +
+	"\x13InternalServerError\x10\xf4\x03\x12\x17\n" +
+	"\x12ServiceUnavailable\x10\xf7\x03*}\n" +
+	"\x12HeaderAppendAction\x12\x1b\n" +
+	"\x17APPEND_IF_EXISTS_OR_ADD\x10\x00\x12\x11\n" +
+	"\rADD_IF_ABSENT\x10\x01\x12\x1e\n" +
+	"\x1aOVERWRITE_IF_EXISTS_OR_ADD\x10\x02\x12\x17\n" +
+	"\x13OVERWRITE_IF_EXISTS\x10\x032c\n" +
+	"\rAuthorization\x12R\n" +
+	"\x05Check\x12#.envoy.service.auth.v3.CheckRequest\x1a$.envoy.service.auth.v3.CheckResponseBCZAgithub.com/VariableSan/go-factory-microservice/pkg/proto/extauthzb\x06proto3"
+
+var (
+	file_extauthz_ext_authz_proto_rawDescOnce sync.Once
+	file_extauthz_ext_authz_proto_rawDescData []byte
+)
+
+func file_extauthz_ext_authz_proto_rawDescGZIP() []byte {
+	file_extauthz_ext_authz_proto_rawDescOnce.Do(func() {
+		file_extauthz_ext_authz_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_extauthz_ext_authz_proto_rawDesc), len(file_extauthz_ext_authz_proto_rawDesc)))
+	})
+	return file_extauthz_ext_authz_proto_rawDescData
+}
+
+var file_extauthz_ext_authz_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_extauthz_ext_authz_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_extauthz_ext_authz_proto_goTypes = []any{
+	(StatusCode)(0),            // 0: envoy.service.auth.v3.StatusCode
+	(HeaderAppendAction)(0),    // 1: envoy.service.auth.v3.HeaderAppendAction
+	(*CheckRequest)(nil),       // 2: envoy.service.auth.v3.CheckRequest
+	(*AttributeContext)(nil),   // 3: envoy.service.auth.v3.AttributeContext
+	(*Request)(nil),            // 4: envoy.service.auth.v3.Request
+	(*HttpRequest)(nil),        // 5: envoy.service.auth.v3.HttpRequest
+	(*CheckResponse)(nil),      // 6: envoy.service.auth.v3.CheckResponse
+	(*Status)(nil),             // 7: envoy.service.auth.v3.Status
+	(*DeniedHttpResponse)(nil), // 8: envoy.service.auth.v3.DeniedHttpResponse
+	(*OkHttpResponse)(nil),     // 9: envoy.service.auth.v3.OkHttpResponse
+	(*HttpStatus)(nil),         // 10: envoy.service.auth.v3.HttpStatus
+	(*HeaderValueOption)(nil),  // 11: envoy.service.auth.v3.HeaderValueOption
+	(*HeaderValue)(nil),        // 12: envoy.service.auth.v3.HeaderValue
+	nil,                        // 13: envoy.service.auth.v3.AttributeContext.ContextExtensionsEntry
+	nil,                        // 14: envoy.service.auth.v3.HttpRequest.HeadersEntry
+}
+var file_extauthz_ext_authz_proto_depIdxs = []int32{
+	3,  // 0: envoy.service.auth.v3.CheckRequest.attributes:type_name -> envoy.service.auth.v3.AttributeContext
+	4,  // 1: envoy.service.auth.v3.AttributeContext.request:type_name -> envoy.service.auth.v3.Request
+	13, // 2: envoy.service.auth.v3.AttributeContext.context_extensions:type_name -> envoy.service.auth.v3.AttributeContext.ContextExtensionsEntry
+	5,  // 3: envoy.service.auth.v3.Request.http:type_name -> envoy.service.auth.v3.HttpRequest
+	14, // 4: envoy.service.auth.v3.HttpRequest.headers:type_name -> envoy.service.auth.v3.HttpRequest.HeadersEntry
+	7,  // 5: envoy.service.auth.v3.CheckResponse.status:type_name -> envoy.service.auth.v3.Status
+	8,  // 6: envoy.service.auth.v3.CheckResponse.denied_response:type_name -> envoy.service.auth.v3.DeniedHttpResponse
+	9,  // 7: envoy.service.auth.v3.CheckResponse.ok_response:type_name -> envoy.service.auth.v3.OkHttpResponse
+	10, // 8: envoy.service.auth.v3.DeniedHttpResponse.status:type_name -> envoy.service.auth.v3.HttpStatus
+	11, // 9: envoy.service.auth.v3.DeniedHttpResponse.headers:type_name -> envoy.service.auth.v3.HeaderValueOption
+	11, // 10: envoy.service.auth.v3.OkHttpResponse.headers:type_name -> envoy.service.auth.v3.HeaderValueOption
+	0,  // 11: envoy.service.auth.v3.HttpStatus.code:type_name -> envoy.service.auth.v3.StatusCode
+	12, // 12: envoy.service.auth.v3.HeaderValueOption.header:type_name -> envoy.service.auth.v3.HeaderValue
+	1,  // 13: envoy.service.auth.v3.HeaderValueOption.append_action:type_name -> envoy.service.auth.v3.HeaderAppendAction
+	2,  // 14: envoy.service.auth.v3.Authorization.Check:input_type -> envoy.service.auth.v3.CheckRequest
+	6,  // 15: envoy.service.auth.v3.Authorization.Check:output_type -> envoy.service.auth.v3.CheckResponse
+	15, // [15:16] is the sub-list for method output_type
+	14, // [14:15] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_extauthz_ext_authz_proto_init() }
+func file_extauthz_ext_authz_proto_init() {
+	if File_extauthz_ext_authz_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_extauthz_ext_authz_proto_rawDesc), len(file_extauthz_ext_authz_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_extauthz_ext_authz_proto_goTypes,
+		DependencyIndexes: file_extauthz_ext_authz_proto_depIdxs,
+		EnumInfos:         file_extauthz_ext_authz_proto_enumTypes,
+		MessageInfos:      file_extauthz_ext_authz_proto_msgTypes,
+	}.Build()
+	File_extauthz_ext_authz_proto = out.File
+	file_extauthz_ext_authz_proto_goTypes = nil
+	file_extauthz_ext_authz_proto_depIdxs = nil
+}
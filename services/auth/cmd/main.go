@@ -12,8 +12,13 @@ import (
 	"github.com/VariableSan/go-factory-microservice/pkg/common/config"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/logger"
+	"github.com/VariableSan/go-factory-microservice/pkg/common/ratelimit"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/tracing"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/keys"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/mail"
+	authMiddleware "github.com/VariableSan/go-factory-microservice/services/auth/internal/middleware"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/password"
 	"github.com/VariableSan/go-factory-microservice/services/auth/internal/server"
 	"github.com/VariableSan/go-factory-microservice/services/auth/internal/service"
 )
@@ -30,7 +35,10 @@ func main() {
 	})
 
 	// Load auth-specific configuration
-	authCfg := config.LoadAuthConfig()
+	authCfg, err := config.LoadAuthConfig()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	// Initialize database connection
 	db, err := database.NewFromURL(authCfg.DatabaseURL)
@@ -74,12 +82,103 @@ func main() {
 		}
 	}
 
+	// Load token signing keys
+	keySet := keys.NewHMACKeySet(authCfg.JWTSecret)
+	if authCfg.JWTSigningKeyFile != "" {
+		keySet, err = keys.LoadKeySet(authCfg.JWTSigningKeyFile, authCfg.JWTVerificationKeyFiles)
+		if err != nil {
+			log.Fatalf("Failed to load signing keys: %v", err)
+		}
+		logger.Info("Loaded signing keys", "kid", keySet.Active().ID, "alg", keySet.Active().Algorithm())
+		if authCfg.JWTAcceptHS256 {
+			keySet.AcceptHMAC(authCfg.JWTSecret)
+			logger.Warn("Still accepting tokens signed with JWT_SECRET; unset JWT_ACCEPT_HS256 once they have expired")
+		}
+	} else {
+		if authCfg.OIDCIssuer != "" {
+			log.Fatalf("OIDC_ISSUER requires JWT_SIGNING_KEY_FILE: ID tokens must be verifiable with published keys")
+		}
+		logger.Warn("JWT_SIGNING_KEY_FILE not set, signing tokens with the shared HS256 secret")
+	}
+
+	// Reverse proxies allowed to report the original client address
+	trustedProxies, err := authMiddleware.ParseTrustedProxies(authCfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to parse TRUSTED_PROXIES: %v", err)
+	}
+
+	// Outgoing email; plug a provider-backed mail.Sender in here for production
+	var mailer mail.Sender = mail.NewLogSender(logger)
+	if authCfg.MailOutboxDir != "" {
+		mailer, err = mail.NewFileSender(authCfg.MailOutboxDir)
+		if err != nil {
+			log.Fatalf("Failed to initialize mail outbox: %v", err)
+		}
+		logger.Info("Writing outgoing email to files", "dir", authCfg.MailOutboxDir)
+	}
+
+	// Password hashing; existing hashes are upgraded to this configuration on login
+	passwordHasher, err := password.New(password.Config{
+		Algorithm:         authCfg.PasswordHashAlgorithm,
+		BcryptCost:        authCfg.BcryptCost,
+		Argon2Memory:      uint32(authCfg.Argon2Memory),
+		Argon2Iterations:  uint32(authCfg.Argon2Iterations),
+		Argon2Parallelism: uint8(authCfg.Argon2Parallelism),
+	})
+	if err != nil {
+		log.Fatalf("Invalid password hashing configuration: %v", err)
+	}
+
+	// Password policy, optionally with an offline breached password dataset
+	passwordPolicy := &password.Policy{
+		MinLength:           authCfg.PasswordMinLength,
+		MaxLength:           authCfg.PasswordMaxLength,
+		MinCharacterClasses: authCfg.PasswordMinCharacterClasses,
+		BannedWords:         authCfg.PasswordBannedWords,
+		MinStrength:         authCfg.PasswordMinStrength,
+	}
+	if authCfg.BreachedPasswordsPath != "" {
+		breached, err := password.LoadBreachedPasswords(authCfg.BreachedPasswordsPath)
+		if err != nil {
+			log.Fatalf("Failed to load breached passwords: %v", err)
+		}
+		passwordPolicy.Breached = breached
+		logger.Info("Checking passwords against breached password dataset", "path", authCfg.BreachedPasswordsPath)
+	}
+	if authCfg.PasswordHashAlgorithm == password.AlgorithmBcrypt {
+		passwordPolicy.MaxBytes = password.BcryptMaxBytes
+	}
+
 	// Initialize auth service
-	authService := service.NewAuthService(db, authCfg.JWTSecret, redisClient, authCfg.TokenExpiry, authCfg.RefreshExpiry, logger)
+	authService := service.NewAuthService(db, keySet, redisClient, mailer, passwordHasher, passwordPolicy, authCfg, logger)
+	if authCfg.BootstrapAdminEmail != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := authService.BootstrapAdmin(ctx, authCfg.BootstrapAdminEmail); err != nil {
+			logger.Warn("Failed to grant bootstrap admin role", "error", err)
+		}
+		cancel()
+	}
+	if authService.OIDCEnabled() {
+		logger.Info("OpenID Connect provider enabled", "issuer", authCfg.OIDCIssuer)
+	}
+
+	// Other services that store user data, such as the feed service, register a
+	// service.AccountDataProvider here so account exports and deletions include their data
+
+	// Per-client request rate limit, shared between instances through Redis
+	var limiter ratelimit.Limiter
+	rateLimitCfg := config.LoadRateLimitConfig()
+	if rateLimitCfg.Requests > 0 {
+		limiter, err = ratelimit.New(redisClient, "auth", rateLimitCfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize rate limiting: %v", err)
+		}
+		logger.Info("Rate limiting enabled", "algorithm", rateLimitCfg.Algorithm, "requests", rateLimitCfg.Requests, "window", rateLimitCfg.Window)
+	}
 
 	// Create servers
-	httpServer := server.NewHTTPServer(authService, authCfg.HTTPPort, authCfg.JWTSecret, logger, tracingManager)
-	grpcServer, err := server.NewGRPCServer(authService, authCfg.GRPCPort, logger, tracingManager)
+	httpServer := server.NewHTTPServer(authService, authCfg.HTTPPort, keySet, trustedProxies, limiter, logger, tracingManager)
+	grpcServer, err := server.NewGRPCServer(authService, authCfg.GRPCPort, keySet, trustedProxies, server.RoutePolicy{PublicPaths: authCfg.ExtAuthzPublicPaths}, limiter, logger, tracingManager)
 	if err != nil {
 		log.Fatalf("Failed to create gRPC server: %v", err)
 	}
@@ -107,6 +206,17 @@ func main() {
 		}
 	}()
 
+	// Delete accounts whose deletion grace period has passed
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	if authCfg.AccountDeletionInterval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info("Starting account deletion job", "interval", authCfg.AccountDeletionInterval)
+			authService.RunAccountDeletion(jobCtx, authCfg.AccountDeletionInterval)
+		}()
+	}
+
 	// Wait for interrupt signal
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -114,6 +224,9 @@ func main() {
 
 	logger.Info("Shutting down servers...")
 
+	// Stop background jobs
+	stopJobs()
+
 	// Graceful shutdown
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
@@ -29,7 +29,11 @@ func main() {
 	}
 
 	// Load auth configuration
-	authCfg := config.LoadAuthConfig()
+	authCfg, err := config.LoadAuthConfig()
+	if err != nil {
+		fmt.Printf("Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Initialize logger
 	log := logger.NewLogger(logger.Config{
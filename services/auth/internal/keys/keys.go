@@ -0,0 +1,299 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Key is a single JWT signing or verification key identified by its kid
+type Key struct {
+	ID         string
+	method     jwt.SigningMethod
+	signingKey interface{}
+	verifyKey  interface{}
+}
+
+// Algorithm returns the JWS algorithm of the key, e.g. RS256
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// KeySet holds the key used to sign new tokens and every key still accepted for verification.
+// Retired keys stay in the set until the tokens they signed have expired.
+type KeySet struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+// hmacKeyID is the kid of the shared HS256 secret
+const hmacKeyID = "hs256"
+
+// NewHMACKeySet creates a key set that signs and verifies with a shared HS256 secret
+func NewHMACKeySet(secret string) *KeySet {
+	key := newHMACKey(secret)
+
+	return &KeySet{
+		active: key,
+		keys:   map[string]*Key{key.ID: key},
+	}
+}
+
+// LoadKeySet loads the active signing key from a PEM encoded private key file, plus retired keys
+// from PEM files holding either private or public keys. Supported key types are RSA (RS256),
+// ECDSA P-256/P-384 (ES256/ES384) and Ed25519 (EdDSA).
+func LoadKeySet(signingKeyFile string, verificationKeyFiles []string) (*KeySet, error) {
+	active, err := loadKeyFile(signingKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if active.signingKey == nil {
+		return nil, fmt.Errorf("signing key file %s does not contain a private key", signingKeyFile)
+	}
+
+	set := &KeySet{
+		active: active,
+		keys:   map[string]*Key{active.ID: active},
+	}
+
+	for _, file := range verificationKeyFiles {
+		key, err := loadKeyFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := set.keys[key.ID]; !exists {
+			set.keys[key.ID] = key
+		}
+	}
+
+	return set, nil
+}
+
+// AcceptHMAC keeps accepting tokens signed with the shared HS256 secret, including legacy tokens
+// without a kid, after switching to asymmetric signing. The secret is never used to sign.
+func (s *KeySet) AcceptHMAC(secret string) {
+	key := newHMACKey(secret)
+	key.signingKey = nil
+	s.keys[key.ID] = key
+}
+
+// Active returns the key used to sign new tokens
+func (s *KeySet) Active() *Key {
+	return s.active
+}
+
+// Sign signs claims with the active key, recording its kid in the token header
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.active.method, claims)
+	token.Header["kid"] = s.active.ID
+	return token.SignedString(s.active.signingKey)
+}
+
+// Keyfunc resolves the verification key of a token from its kid header
+func (s *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	// Only legacy HS256 tokens are allowed to omit the kid
+	key := s.keys[hmacKeyID]
+	if kid, ok := token.Header["kid"].(string); ok {
+		key = s.keys[kid]
+	}
+
+	if key == nil {
+		return nil, ErrUnknownKey
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.verifyKey, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the set; shared HMAC secrets are never published
+func (s *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+
+	// Publish the active key first so clients find it without scanning
+	if jwk, ok := toJWK(s.active); ok {
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	for id, key := range s.keys {
+		if id == s.active.ID {
+			continue
+		}
+		if jwk, ok := toJWK(key); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+
+	return jwks
+}
+
+func newHMACKey(secret string) *Key {
+	return &Key{
+		ID:         hmacKeyID,
+		method:     jwt.SigningMethodHS256,
+		signingKey: []byte(secret),
+		verifyKey:  []byte(secret),
+	}
+}
+
+func loadKeyFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+
+	parsed, err := parsePEMBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key file %s: %w", path, err)
+	}
+
+	key := &Key{}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.signingKey = signer
+		key.verifyKey = signer.Public()
+	} else {
+		key.verifyKey = parsed
+	}
+
+	switch pub := key.verifyKey.(type) {
+	case *rsa.PublicKey:
+		key.method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			key.method = jwt.SigningMethodES256
+		case elliptic.P384():
+			key.method = jwt.SigningMethodES384
+		default:
+			return nil, fmt.Errorf("unsupported elliptic curve in %s", path)
+		}
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T in %s", pub, path)
+	}
+
+	jwk, _ := toJWK(key)
+	key.ID = thumbprint(jwk)
+
+	return key, nil
+}
+
+func parsePEMBlock(block *pem.Block) (interface{}, error) {
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+func toJWK(key *Key) (JWK, bool) {
+	jwk := JWK{
+		KeyID:     key.ID,
+		Use:       "sig",
+		Algorithm: key.method.Alg(),
+	}
+
+	switch pub := key.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encode(pub.N.Bytes())
+		jwk.E = encode(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.KeyType = "EC"
+		jwk.Curve = pub.Curve.Params().Name
+		jwk.X = encode(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = encode(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = encode(pub)
+	default:
+		return JWK{}, false
+	}
+
+	return jwk, true
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint used as the kid
+func thumbprint(jwk JWK) string {
+	var members interface{}
+	switch jwk.KeyType {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Curve, jwk.KeyType, jwk.X, jwk.Y}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	}
+
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return encode(sum[:])
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,81 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/logger"
+	"github.com/google/uuid"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers emails. Production deployments plug in an implementation backed by their mail
+// provider; LogSender and FileSender are meant for local development.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogSender writes every message to the log instead of sending it
+type LogSender struct {
+	logger *logger.Logger
+}
+
+func NewLogSender(logger *logger.Logger) *LogSender {
+	return &LogSender{
+		logger: logger.WithComponent("mail"),
+	}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg Message) error {
+	s.logger.Info("Email not sent, logging instead", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
+
+// FileSender writes every message to its own .eml file in a directory
+type FileSender struct {
+	dir string
+}
+
+// NewFileSender creates dir if needed and returns a sender writing into it
+func NewFileSender(dir string) (*FileSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	return &FileSender{
+		dir: dir,
+	}, nil
+}
+
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue(msg.Subject))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405"), uuid.New().String())
+	if err := os.WriteFile(filepath.Join(s.dir, name), []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	return nil
+}
+
+// headerValue strips line breaks so a value cannot inject further headers
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -2,51 +2,172 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/VariableSan/go-factory-microservice/pkg/common/response"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/service"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// AuthMiddleware validates JWT tokens and adds user context
-func AuthMiddleware(jwtSecret string) func(http.Handler) http.Handler {
+// TokenRevocationChecker reports whether a token, or the session it belongs to, has been revoked,
+// and whether an impersonation token was revoked along with the sessions of either user it names
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, tokenID, sessionID string) (bool, error)
+	IsImpersonationRevoked(ctx context.Context, actorID, userID string, issuedAt time.Time) (bool, error)
+}
+
+// Authenticator checks the credentials that cannot be verified from the request alone: it
+// reports revoked tokens and resolves personal API keys
+type Authenticator interface {
+	TokenRevocationChecker
+	AuthenticateAPIKey(ctx context.Context, key string) (*service.APIKeyPrincipal, error)
+}
+
+// HeaderAPIKey carries a personal API key, as an alternative to "Authorization: ApiKey <key>"
+const HeaderAPIKey = "X-API-Key"
+
+var (
+	errInvalidToken  = errors.New("invalid token")
+	errRevokedToken  = errors.New("token has been revoked")
+	errInvalidAPIKey = errors.New("invalid api key")
+	errNoCredentials = errors.New("authorization required")
+)
+
+// AuthMiddleware authenticates requests with a JWT access token or a personal API key, rejects
+// revoked tokens and adds user context
+func AuthMiddleware(keyfunc jwt.Keyfunc, authenticator Authenticator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			token := r.Header.Get("Authorization")
-			if token == "" {
+			claims, err := authenticateCredentials(r.Context(), r.Header.Get("Authorization"), r.Header.Get(HeaderAPIKey), keyfunc, authenticator)
+			if err == errNoCredentials {
 				response.Unauthorized(w, "Authorization header required")
 				return
 			}
-
-			// Remove "Bearer " prefix if present
-			if len(token) > 7 && strings.ToLower(token[:7]) == "bearer " {
-				token = token[7:]
+			if err == errRevokedToken {
+				response.Unauthorized(w, "Token has been revoked")
+				return
 			}
-
-			claims := &JWTClaims{}
-			parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-				return []byte(jwtSecret), nil
-			})
-
-			if err != nil || !parsedToken.Valid {
+			if err == errInvalidAPIKey {
+				response.Unauthorized(w, "Invalid API key")
+				return
+			}
+			if err != nil {
 				response.Unauthorized(w, "Invalid token")
 				return
 			}
 
-			// Add user info to context
-			ctx := context.WithValue(r.Context(), "userID", claims.UserID)
-			ctx = context.WithValue(ctx, "email", claims.Email)
-			ctx = context.WithValue(ctx, "roles", claims.Roles)
-
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(contextWithClaims(r.Context(), claims)))
 		})
 	}
 }
 
 type JWTClaims struct {
-	UserID string   `json:"user_id"`
-	Email  string   `json:"email"`
-	Roles  []string `json:"roles"`
+	UserID      string   `json:"user_id"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+	SessionID   string   `json:"sid"`
+	TokenType   string   `json:"token_type"`
+	Scope       string   `json:"scope"`
+	ClientID    string   `json:"client_id"`
+	OrgID       string   `json:"org_id"`
+	OrgRole     string   `json:"org_role"`
+	// Actor names the administrator impersonating the user, if any
+	Actor *service.ActorClaim `json:"act"`
+	// APIKeyID is set instead of a session when the request was authenticated with an API key
+	APIKeyID string `json:"-"`
 	jwt.RegisteredClaims
 }
+
+// serviceAccount reports whether the token was issued to a service account rather than a user
+func (c *JWTClaims) serviceAccount() bool {
+	return c.UserID == "" && c.ClientID != ""
+}
+
+// authenticateCredentials authenticates a request with the value of its Authorization header,
+// which holds a bearer token or an ApiKey, or with the value of its X-API-Key header
+func authenticateCredentials(ctx context.Context, authorization, apiKey string, keyfunc jwt.Keyfunc, authenticator Authenticator) (*JWTClaims, error) {
+	if apiKey == "" && len(authorization) > 7 && strings.ToLower(authorization[:7]) == "apikey " {
+		apiKey = authorization[7:]
+	}
+	if apiKey != "" {
+		return authenticateAPIKey(ctx, apiKey, authenticator)
+	}
+	if authorization == "" {
+		return nil, errNoCredentials
+	}
+
+	return authenticate(ctx, authorization, keyfunc, authenticator)
+}
+
+// authenticateAPIKey resolves a personal API key to claims of its owner, limited to its scopes
+func authenticateAPIKey(ctx context.Context, key string, authenticator Authenticator) (*JWTClaims, error) {
+	principal, err := authenticator.AuthenticateAPIKey(ctx, key)
+	if err != nil {
+		return nil, errInvalidAPIKey
+	}
+
+	return &JWTClaims{
+		UserID:      principal.User.ID,
+		Email:       principal.User.Email,
+		Roles:       principal.User.Roles,
+		Permissions: principal.User.Permissions,
+		Scope:       strings.Join(principal.Scopes, " "),
+		APIKeyID:    principal.KeyID,
+	}, nil
+}
+
+// authenticate parses an access token from an Authorization header value and checks it has not been revoked
+func authenticate(ctx context.Context, token string, keyfunc jwt.Keyfunc, revocations TokenRevocationChecker) (*JWTClaims, error) {
+	// Remove "Bearer " prefix if present
+	if len(token) > 7 && strings.ToLower(token[:7]) == "bearer " {
+		token = token[7:]
+	}
+
+	claims := &JWTClaims{}
+	parsedToken, err := jwt.ParseWithClaims(token, claims, keyfunc)
+
+	// Refresh and ID tokens are signed with the same keys but must never authorize a request
+	if err != nil || !parsedToken.Valid || claims.TokenType != "access" {
+		return nil, errInvalidToken
+	}
+
+	revoked, err := revocations.IsTokenRevoked(ctx, claims.ID, claims.SessionID)
+	if err != nil || revoked {
+		return nil, errRevokedToken
+	}
+
+	if claims.Actor != nil {
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+		revoked, err := revocations.IsImpersonationRevoked(ctx, claims.Actor.Subject, claims.UserID, issuedAt)
+		if err != nil || revoked {
+			return nil, errRevokedToken
+		}
+	}
+
+	return claims, nil
+}
+
+// contextWithClaims adds user info to context
+func contextWithClaims(ctx context.Context, claims *JWTClaims) context.Context {
+	ctx = context.WithValue(ctx, "userID", claims.UserID)
+	ctx = context.WithValue(ctx, "email", claims.Email)
+	ctx = context.WithValue(ctx, "roles", claims.Roles)
+	ctx = context.WithValue(ctx, "permissions", claims.Permissions)
+	ctx = context.WithValue(ctx, "sessionID", claims.SessionID)
+	ctx = context.WithValue(ctx, "clientID", claims.ClientID)
+	ctx = context.WithValue(ctx, "scopes", strings.Fields(claims.Scope))
+	ctx = context.WithValue(ctx, "apiKeyID", claims.APIKeyID)
+	ctx = context.WithValue(ctx, "orgID", claims.OrgID)
+	ctx = context.WithValue(ctx, "orgRole", claims.OrgRole)
+	if claims.Actor != nil {
+		ctx = context.WithValue(ctx, "actorID", claims.Actor.Subject)
+	}
+	return ctx
+}
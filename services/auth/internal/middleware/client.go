@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/service"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// DeviceNameHeader lets clients label the session they create, e.g. "Work laptop"
+const DeviceNameHeader = "X-Device-Name"
+
+// requestIDMetadata carries the request ID of gRPC calls, as X-Request-Id does for HTTP
+const requestIDMetadata = "x-request-id"
+
+// Limits matching the sessions and audit_events table columns, so oversized client values cannot
+// break Login
+const (
+	maxDeviceNameLength = 255
+	maxUserAgentLength  = 1024
+	maxRequestIDLength  = 255
+)
+
+// TrustedProxies is a set of networks whose forwarding headers are believed
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses a list of CIDRs or single IP addresses
+func ParseTrustedProxies(values []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+func (p TrustedProxies) contains(ip net.IP) bool {
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the address of the original client. Forwarded addresses are only honoured
+// while the hop that added them is a trusted proxy, so clients cannot spoof their IP.
+func (p TrustedProxies) clientIP(remoteAddr string, forwardedFor []string) string {
+	ip := net.ParseIP(hostOnly(remoteAddr))
+	if ip == nil {
+		return ""
+	}
+
+	var hops []string
+	for _, header := range forwardedFor {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+
+	// Walk from the nearest hop back towards the client
+	for i := len(hops) - 1; i >= 0 && p.contains(ip); i-- {
+		forwarded := net.ParseIP(strings.TrimSpace(hops[i]))
+		if forwarded == nil {
+			break
+		}
+		ip = forwarded
+	}
+
+	return ip.String()
+}
+
+// ClientInfo records the caller's IP address, user agent, device name and request ID for session
+// tracking and auditing. It must run after chi's RequestID middleware.
+// X-Forwarded-For is only trusted when the request comes through one of trustedProxies.
+func ClientInfo(trustedProxies TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := service.ContextWithClientInfo(r.Context(), service.ClientInfo{
+				IPAddress:  trustedProxies.clientIP(r.RemoteAddr, r.Header.Values("X-Forwarded-For")),
+				UserAgent:  truncate(r.UserAgent(), maxUserAgentLength),
+				DeviceName: truncate(r.Header.Get(DeviceNameHeader), maxDeviceNameLength),
+				RequestID:  truncate(middleware.GetReqID(r.Context()), maxRequestIDLength),
+			})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ClientInfoUnaryInterceptor is the gRPC counterpart of ClientInfo
+func ClientInfoUnaryInterceptor(trustedProxies TrustedProxies) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		client := service.ClientInfo{}
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		if p, ok := peer.FromContext(ctx); ok {
+			client.IPAddress = trustedProxies.clientIP(p.Addr.String(), md.Get("x-forwarded-for"))
+		}
+		if userAgent := md.Get("user-agent"); len(userAgent) > 0 {
+			client.UserAgent = truncate(userAgent[0], maxUserAgentLength)
+		}
+		if deviceName := md.Get(strings.ToLower(DeviceNameHeader)); len(deviceName) > 0 {
+			client.DeviceName = truncate(deviceName[0], maxDeviceNameLength)
+		}
+		if requestID := md.Get(requestIDMetadata); len(requestID) > 0 && requestID[0] != "" {
+			client.RequestID = truncate(requestID[0], maxRequestIDLength)
+		} else {
+			client.RequestID = uuid.New().String()
+		}
+
+		return handler(service.ContextWithClientInfo(ctx, client), req)
+	}
+}
+
+// RateLimitKeyByClientIP keys rate limits by the client address resolved by ClientInfo, so
+// clients behind trusted proxies are counted separately
+func RateLimitKeyByClientIP(r *http.Request) string {
+	if ip := service.ClientInfoFromContext(r.Context()).IPAddress; ip != "" {
+		return "ip:" + ip
+	}
+	return ""
+}
+
+// GRPCRateLimitKeyByClientIP is the gRPC counterpart of RateLimitKeyByClientIP
+func GRPCRateLimitKeyByClientIP(ctx context.Context, _ *grpc.UnaryServerInfo) string {
+	if ip := service.ClientInfoFromContext(ctx).IPAddress; ip != "" {
+		return "ip:" + ip
+	}
+	return ""
+}
+
+// hostOnly strips the port from an address if present
+func hostOnly(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
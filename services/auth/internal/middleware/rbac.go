@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/response"
+)
+
+// RequireRole allows the request only if the authenticated user has at least one of roles.
+// It must be used after AuthMiddleware.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasAnyRole(r.Context(), roles) {
+				response.Forbidden(w, "Insufficient role")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequirePermission allows the request only if the authenticated user has all of permissions.
+// It must be used after AuthMiddleware.
+func RequirePermission(permissions ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasAllPermissions(r.Context(), permissions) {
+				response.Forbidden(w, "Insufficient permissions")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HasPermissions reports whether the authenticated caller in ctx has all of permissions
+func HasPermissions(ctx context.Context, permissions ...string) bool {
+	return hasAllPermissions(ctx, permissions)
+}
+
+func hasAnyRole(ctx context.Context, roles []string) bool {
+	userRoles, _ := ctx.Value("roles").([]string)
+	for _, role := range roles {
+		if contains(userRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAllPermissions(ctx context.Context, permissions []string) bool {
+	userPermissions, _ := ctx.Value("permissions").([]string)
+	for _, permission := range permissions {
+		if !contains(userPermissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAllScopes(ctx context.Context, scopes []string) bool {
+	grantedScopes, _ := ctx.Value("scopes").([]string)
+	for _, scope := range scopes {
+		if !contains(grantedScopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// rangePrefixLength is the number of hex digits of a SHA-1 hash that select a k-anonymity range
+const rangePrefixLength = 5
+
+// BreachChecker reports whether a password is known from data breaches
+type BreachChecker interface {
+	Breached(password string) (bool, error)
+}
+
+// BreachedPasswords is an offline copy of a Pwned Passwords style dataset of SHA-1 password hashes.
+// Lookups use k-anonymity ranges: the first five hex digits of a password's hash select a range,
+// which is then searched for the remaining 35, so the dataset can be split into range files that
+// are read on demand.
+type BreachedPasswords struct {
+	// dir holds one <PREFIX>.txt file of SUFFIX:COUNT lines per range, as written by the Pwned
+	// Passwords downloader; empty when the dataset was loaded into ranges
+	dir    string
+	ranges map[string][]string
+}
+
+// LoadBreachedPasswords opens a breached password dataset. path is either a directory of range
+// files named <PREFIX>.txt with SUFFIX:COUNT lines, or a single file of HASH or HASH:COUNT lines,
+// which is loaded into memory.
+func LoadBreachedPasswords(path string) (*BreachedPasswords, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password dataset: %w", err)
+	}
+	if info.IsDir() {
+		return &BreachedPasswords{dir: path}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password dataset: %w", err)
+	}
+	defer file.Close()
+
+	ranges := make(map[string][]string)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		hash, ok := parseHashLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("breached password dataset line %d is not a SHA-1 hash", line)
+		}
+		prefix := hash[:rangePrefixLength]
+		ranges[prefix] = append(ranges[prefix], hash[rangePrefixLength:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password dataset: %w", err)
+	}
+
+	for _, suffixes := range ranges {
+		sort.Strings(suffixes)
+	}
+	return &BreachedPasswords{ranges: ranges}, nil
+}
+
+// Breached reports whether the SHA-1 hash of password is in the dataset
+func (b *BreachedPasswords) Breached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:rangePrefixLength], hash[rangePrefixLength:]
+
+	if b.dir == "" {
+		suffixes := b.ranges[prefix]
+		i := sort.SearchStrings(suffixes, suffix)
+		return i < len(suffixes) && suffixes[i] == suffix, nil
+	}
+
+	file, err := os.Open(filepath.Join(b.dir, prefix+".txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		// The dataset does not cover this range
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	return rangeContains(file, suffix)
+}
+
+// rangeContains searches a range file of SUFFIX:COUNT lines for suffix. Padding entries with a
+// count of zero, which the Pwned Passwords API can add, do not count.
+func rangeContains(r io.Reader, suffix string) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		entry, count, _ := strings.Cut(line, ":")
+		if strings.EqualFold(entry, suffix) {
+			return count != "0", nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// parseHashLine returns the upper-case hash of a HASH or HASH:COUNT line, skipping blank lines
+// and entries with a count of zero
+func parseHashLine(line string) (string, bool) {
+	hash, count, _ := strings.Cut(strings.TrimSpace(line), ":")
+	if hash == "" || count == "0" {
+		return "", false
+	}
+	return strings.ToUpper(hash), true
+}
@@ -0,0 +1,214 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Supported hashing algorithms, named by their PHC string format identifiers
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// BcryptMaxBytes is the longest password bcrypt can hash, in bytes
+const BcryptMaxBytes = 72
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported password hashing algorithm")
+	ErrMalformedHash        = errors.New("malformed password hash")
+)
+
+var b64 = base64.RawStdEncoding
+
+// Hasher hashes passwords with its configured algorithm and parameters. Verify accepts hashes of
+// every supported algorithm, so passwords hashed under an earlier configuration keep working;
+// NeedsRehash tells which of them should be replaced with a hash from Hash.
+type Hasher interface {
+	// Hash returns the PHC string of a new salted hash of password
+	Hash(password string) (string, error)
+	// Verify reports whether password matches hash
+	Verify(password, hash string) (bool, error)
+	// NeedsRehash reports whether hash was made with another algorithm or other parameters
+	NeedsRehash(hash string) bool
+}
+
+// Config selects the algorithm and parameters of a Hasher
+type Config struct {
+	// Algorithm is AlgorithmArgon2id or AlgorithmBcrypt
+	Algorithm string
+	// BcryptCost is the bcrypt work factor
+	BcryptCost int
+	// Argon2id parameters: memory in KiB, number of passes and degree of parallelism
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+// New returns the Hasher for cfg
+func New(cfg Config) (Hasher, error) {
+	switch cfg.Algorithm {
+	case AlgorithmArgon2id:
+		if cfg.Argon2Memory == 0 || cfg.Argon2Iterations == 0 || cfg.Argon2Parallelism == 0 {
+			return nil, fmt.Errorf("argon2id memory, iterations and parallelism must be positive")
+		}
+		return &Argon2id{
+			Memory:      cfg.Argon2Memory,
+			Iterations:  cfg.Argon2Iterations,
+			Parallelism: cfg.Argon2Parallelism,
+		}, nil
+	case AlgorithmBcrypt:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		return &Bcrypt{Cost: cfg.BcryptCost}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, cfg.Algorithm)
+	}
+}
+
+// Bcrypt hashes passwords with bcrypt. Its hashes keep bcrypt's own $2a$<cost>$<salt+hash> form,
+// which PHC string parsers accept as is.
+type Bcrypt struct {
+	Cost int
+}
+
+func (h *Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+func (h *Bcrypt) Verify(password, hash string) (bool, error) {
+	return Verify(password, hash)
+}
+
+func (h *Bcrypt) NeedsRehash(hash string) bool {
+	if Identify(hash) != AlgorithmBcrypt {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.Cost
+}
+
+// Argon2id hashes passwords with Argon2id (RFC 9106) into PHC strings of the form
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+type Argon2id struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+func (h *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.Memory, h.Parallelism, argon2KeyLength)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", AlgorithmArgon2id, argon2.Version,
+		h.Memory, h.Iterations, h.Parallelism, b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+func (h *Argon2id) Verify(password, hash string) (bool, error) {
+	return Verify(password, hash)
+}
+
+func (h *Argon2id) NeedsRehash(hash string) bool {
+	params, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return params.memory != h.Memory || params.iterations != h.Iterations ||
+		params.parallelism != h.Parallelism || len(params.key) != argon2KeyLength
+}
+
+// Identify returns the algorithm a hash was made with, or "" if it is not a supported format
+func Identify(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return AlgorithmArgon2id
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return AlgorithmBcrypt
+	default:
+		return ""
+	}
+}
+
+// Verify reports whether password matches a hash of any supported algorithm, using the
+// parameters stored in the hash
+func Verify(password, hash string) (bool, error) {
+	switch Identify(hash) {
+	case AlgorithmBcrypt:
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", ErrMalformedHash, err)
+		}
+		return true, nil
+	case AlgorithmArgon2id:
+		params, err := parseArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+		key := argon2.IDKey([]byte(password), params.salt, params.iterations, params.memory, params.parallelism, uint32(len(params.key)))
+		return subtle.ConstantTimeCompare(key, params.key) == 1, nil
+	default:
+		return false, ErrUnsupportedAlgorithm
+	}
+}
+
+type argon2idParams struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+// parseArgon2id parses a PHC string made by Argon2id.Hash
+func parseArgon2id(hash string) (*argon2idParams, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrMalformedHash
+	}
+
+	params := &argon2idParams{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return nil, ErrMalformedHash
+	}
+	if params.memory == 0 || params.iterations == 0 || params.parallelism == 0 {
+		return nil, ErrMalformedHash
+	}
+
+	var err error
+	if params.salt, err = b64.DecodeString(parts[4]); err != nil {
+		return nil, ErrMalformedHash
+	}
+	if params.key, err = b64.DecodeString(parts[5]); err != nil || len(params.key) == 0 {
+		return nil, ErrMalformedHash
+	}
+
+	return params, nil
+}
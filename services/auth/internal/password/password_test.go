@@ -0,0 +1,180 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Small parameters keep the tests fast
+var (
+	testArgon2id = &Argon2id{Memory: 64, Iterations: 1, Parallelism: 1}
+	testBcrypt   = &Bcrypt{Cost: bcrypt.MinCost}
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "argon2id", cfg: Config{Algorithm: AlgorithmArgon2id, Argon2Memory: 64, Argon2Iterations: 1, Argon2Parallelism: 1}},
+		{name: "argon2id without memory", cfg: Config{Algorithm: AlgorithmArgon2id, Argon2Iterations: 1, Argon2Parallelism: 1}, wantErr: true},
+		{name: "bcrypt", cfg: Config{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.DefaultCost}},
+		{name: "bcrypt cost too low", cfg: Config{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost - 1}, wantErr: true},
+		{name: "bcrypt cost too high", cfg: Config{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MaxCost + 1}, wantErr: true},
+		{name: "unsupported algorithm", cfg: Config{Algorithm: "scrypt"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHashVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		hasher   Hasher
+		password string
+	}{
+		{name: "argon2id", hasher: testArgon2id, password: "correct horse battery staple"},
+		{name: "argon2id unicode", hasher: testArgon2id, password: "пароль-密码-🔑"},
+		{name: "argon2id empty", hasher: testArgon2id, password: ""},
+		{name: "bcrypt", hasher: testBcrypt, password: "correct horse battery staple"},
+		{name: "bcrypt at 72 bytes", hasher: testBcrypt, password: strings.Repeat("a", BcryptMaxBytes)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.hasher.Hash(tt.password)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+
+			if ok, err := tt.hasher.Verify(tt.password, hash); err != nil || !ok {
+				t.Errorf("Verify(password) = %v, %v, want true", ok, err)
+			}
+			if ok, err := Verify("x"+tt.password, hash); err != nil || ok {
+				t.Errorf("Verify(other password) = %v, %v, want false", ok, err)
+			}
+			if tt.hasher.NeedsRehash(hash) {
+				t.Errorf("NeedsRehash() = true for a fresh hash")
+			}
+
+			again, err := tt.hasher.Hash(tt.password)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if again == hash {
+				t.Errorf("Hash() returned the same hash twice; salts must differ")
+			}
+		})
+	}
+}
+
+func TestBcryptHashTooLong(t *testing.T) {
+	if _, err := testBcrypt.Hash(strings.Repeat("a", BcryptMaxBytes+1)); err == nil {
+		t.Error("Hash() accepted a password longer than bcrypt can hash")
+	}
+}
+
+func TestVerifyMalformedHash(t *testing.T) {
+	valid, err := testArgon2id.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(valid, "$")
+
+	tests := []struct {
+		name    string
+		hash    string
+		wantErr error
+	}{
+		{name: "empty", hash: "", wantErr: ErrUnsupportedAlgorithm},
+		{name: "unknown algorithm", hash: "$scrypt$ln=15,r=8,p=1$c2FsdA$aGFzaA", wantErr: ErrUnsupportedAlgorithm},
+		{name: "plain text", hash: "password", wantErr: ErrUnsupportedAlgorithm},
+		{name: "argon2id missing key", hash: strings.Join(parts[:5], "$"), wantErr: ErrMalformedHash},
+		{name: "argon2id other version", hash: strings.Join([]string{"", parts[1], "v=16", parts[3], parts[4], parts[5]}, "$"), wantErr: ErrMalformedHash},
+		{name: "argon2id bad parameters", hash: strings.Join([]string{"", parts[1], parts[2], "m=64,t=1", parts[4], parts[5]}, "$"), wantErr: ErrMalformedHash},
+		{name: "argon2id zero memory", hash: strings.Join([]string{"", parts[1], parts[2], "m=0,t=1,p=1", parts[4], parts[5]}, "$"), wantErr: ErrMalformedHash},
+		{name: "argon2id salt not base64", hash: strings.Join([]string{"", parts[1], parts[2], parts[3], "!!", parts[5]}, "$"), wantErr: ErrMalformedHash},
+		{name: "argon2id empty key", hash: strings.Join([]string{"", parts[1], parts[2], parts[3], parts[4], ""}, "$"), wantErr: ErrMalformedHash},
+		{name: "bcrypt truncated", hash: "$2a$10$abc", wantErr: ErrMalformedHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := Verify("password", tt.hash)
+			if ok || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() = %v, %v, want false, %v", ok, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	argon2Hash, err := testArgon2id.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := testBcrypt.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		hasher Hasher
+		hash   string
+		want   bool
+	}{
+		{name: "argon2id same parameters", hasher: testArgon2id, hash: argon2Hash},
+		{name: "argon2id more memory", hasher: &Argon2id{Memory: 128, Iterations: 1, Parallelism: 1}, hash: argon2Hash, want: true},
+		{name: "argon2id more iterations", hasher: &Argon2id{Memory: 64, Iterations: 2, Parallelism: 1}, hash: argon2Hash, want: true},
+		{name: "argon2id more parallelism", hasher: &Argon2id{Memory: 64, Iterations: 1, Parallelism: 2}, hash: argon2Hash, want: true},
+		{name: "argon2id from bcrypt", hasher: testArgon2id, hash: bcryptHash, want: true},
+		{name: "argon2id malformed", hasher: testArgon2id, hash: "$argon2id$v=19$m=64", want: true},
+		{name: "bcrypt same cost", hasher: testBcrypt, hash: bcryptHash},
+		{name: "bcrypt higher cost", hasher: &Bcrypt{Cost: bcrypt.MinCost + 1}, hash: bcryptHash, want: true},
+		{name: "bcrypt from argon2id", hasher: testBcrypt, hash: argon2Hash, want: true},
+		{name: "bcrypt malformed", hasher: testBcrypt, hash: "$2a$xx$", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	tests := []struct {
+		hash string
+		want string
+	}{
+		{hash: "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA", want: AlgorithmArgon2id},
+		{hash: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", want: AlgorithmBcrypt},
+		{hash: "$2b$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", want: AlgorithmBcrypt},
+		{hash: "$2y$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", want: AlgorithmBcrypt},
+		{hash: "$argon2i$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA", want: ""},
+		{hash: "$1$salt$hash", want: ""},
+		{hash: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hash, func(t *testing.T) {
+			if got := Identify(tt.hash); got != tt.want {
+				t.Errorf("Identify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
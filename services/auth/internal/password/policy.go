@@ -0,0 +1,155 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rules of a Policy, reported in violations
+const (
+	RuleMinLength        = "min_length"
+	RuleMaxLength        = "max_length"
+	RuleCharacterClasses = "character_classes"
+	RuleBannedWord       = "banned_word"
+	RuleStrength         = "strength"
+	RuleBreached         = "breached"
+)
+
+// minBannedWordLength keeps short names and email fragments from banning most passwords
+const minBannedWordLength = 3
+
+// Policy decides which passwords users may choose
+type Policy struct {
+	// MinLength and MaxLength bound the number of characters; zero means no bound
+	MinLength int
+	MaxLength int
+	// MaxBytes bounds the length of the UTF-8 encoding, for hashing algorithms that only accept
+	// so many bytes, such as bcrypt (BcryptMaxBytes); zero means no bound
+	MaxBytes int
+	// MinCharacterClasses is how many of lowercase letters, uppercase letters, digits and other
+	// characters a password must mix
+	MinCharacterClasses int
+	// BannedWords may not appear anywhere in a password, ignoring case
+	BannedWords []string
+	// MinStrength is the lowest acceptable Strength score, from 0 to 4
+	MinStrength int
+	// Breached rejects passwords known from data breaches; nil disables the check
+	Breached BreachChecker
+}
+
+// Violation is a rule of the policy a password breaks
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyError lists every rule a rejected password breaks
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return "password does not meet the password policy: " + strings.Join(messages, "; ")
+}
+
+// Check returns a *PolicyError if password breaks the policy. userInputs are personal data of
+// the user, such as the email address and names, that the password must not contain. Other
+// errors mean the breached password check failed.
+func (p *Policy) Check(password string, userInputs ...string) error {
+	var violations []Violation
+	violate := func(rule, format string, args ...interface{}) {
+		violations = append(violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 && length < p.MinLength {
+		violate(RuleMinLength, "must be at least %d characters long", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violate(RuleMaxLength, "must be at most %d characters long", p.MaxLength)
+	} else if p.MaxBytes > 0 && len(password) > p.MaxBytes {
+		violate(RuleMaxLength, "must be at most %d bytes long when encoded as UTF-8", p.MaxBytes)
+	}
+
+	if classes := characterClasses(password); classes < p.MinCharacterClasses {
+		violate(RuleCharacterClasses, "must mix at least %d of lowercase letters, uppercase letters, digits and symbols", p.MinCharacterClasses)
+	}
+
+	personal := personalWords(userInputs)
+	lower := strings.ToLower(password)
+	seen := make(map[string]bool)
+	for _, word := range append(append([]string{}, p.BannedWords...), personal...) {
+		word = strings.ToLower(word)
+		if len(word) >= minBannedWordLength && !seen[word] && strings.Contains(lower, word) {
+			seen[word] = true
+			violate(RuleBannedWord, "must not contain %q", word)
+		}
+	}
+
+	if score := Strength(password, personal...); score < p.MinStrength {
+		violate(RuleStrength, "is too easy to guess (strength %d of 4, at least %d required)", score, p.MinStrength)
+	}
+
+	if p.Breached != nil {
+		breached, err := p.Breached.Breached(password)
+		if err != nil {
+			return fmt.Errorf("failed to check breached passwords: %w", err)
+		}
+		if breached {
+			violate(RuleBreached, "has appeared in a data breach and must not be used")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// characterClasses counts which of lowercase letters, uppercase letters, digits and other
+// characters password contains
+func characterClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
+
+// personalWords splits personal data such as "jane.doe@example.com" into the words it is made of.
+// The domains of email addresses are left out, as they are often shared by many users.
+func personalWords(userInputs []string) []string {
+	var words []string
+	for _, input := range userInputs {
+		input = strings.ToLower(input)
+		if i := strings.LastIndex(input, "@"); i >= 0 {
+			input = input[:i]
+		}
+		if len(input) >= minBannedWordLength {
+			words = append(words, input)
+		}
+		for _, word := range strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if len(word) >= minBannedWordLength && word != input {
+				words = append(words, word)
+			}
+		}
+	}
+	return words
+}
@@ -0,0 +1,202 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeBreaches reports the listed passwords as breached, or fails with err
+type fakeBreaches struct {
+	passwords []string
+	err       error
+}
+
+func (f *fakeBreaches) Breached(password string) (bool, error) {
+	for _, p := range f.passwords {
+		if p == password {
+			return true, f.err
+		}
+	}
+	return false, f.err
+}
+
+func TestPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     Policy
+		password   string
+		userInputs []string
+		wantRules  []string
+	}{
+		{name: "empty policy", password: "a"},
+		{name: "long enough", policy: Policy{MinLength: 8}, password: "abcdefgh"},
+		{name: "too short", policy: Policy{MinLength: 8}, password: "abcdefg", wantRules: []string{RuleMinLength}},
+		{name: "length counts characters", policy: Policy{MinLength: 4}, password: "äöü", wantRules: []string{RuleMinLength}},
+		{name: "too long", policy: Policy{MaxLength: 4}, password: "abcde", wantRules: []string{RuleMaxLength}},
+		{name: "at the maximum", policy: Policy{MaxLength: 4}, password: "äöüß"},
+		{name: "within the byte limit", policy: Policy{MaxLength: 72, MaxBytes: 72}, password: strings.Repeat("a", 72)},
+		{name: "over the byte limit", policy: Policy{MaxLength: 72, MaxBytes: 72}, password: strings.Repeat("ä", 37), wantRules: []string{RuleMaxLength}},
+		{name: "over both limits", policy: Policy{MaxLength: 72, MaxBytes: 72}, password: strings.Repeat("ä", 73), wantRules: []string{RuleMaxLength}},
+		{name: "enough character classes", policy: Policy{MinCharacterClasses: 3}, password: "abcDEF123"},
+		{name: "too few character classes", policy: Policy{MinCharacterClasses: 3}, password: "abcdef123", wantRules: []string{RuleCharacterClasses}},
+		{name: "symbols are a class", policy: Policy{MinCharacterClasses: 4}, password: "aB3 "},
+		{name: "banned word", policy: Policy{BannedWords: []string{"acme"}}, password: "myACMEpass", wantRules: []string{RuleBannedWord}},
+		{name: "short banned words are ignored", policy: Policy{BannedWords: []string{"ab"}}, password: "abcdef"},
+		{name: "email local part", password: "janedoe-rocks", userInputs: []string{"jane.doe@example.com"}, wantRules: []string{RuleBannedWord, RuleBannedWord}},
+		{name: "email domain is allowed", password: "example-rocks", userInputs: []string{"jane.doe@example.com"}},
+		{name: "name", password: "Smith2024!", userInputs: []string{"Jane Smith"}, wantRules: []string{RuleBannedWord}},
+		{name: "too weak", policy: Policy{MinStrength: 2}, password: "password1", wantRules: []string{RuleStrength}},
+		{name: "strong enough", policy: Policy{MinStrength: 3}, password: "vT9#qLw2!zRm"},
+		{name: "breached", policy: Policy{Breached: &fakeBreaches{passwords: []string{"hunter2"}}}, password: "hunter2", wantRules: []string{RuleBreached}},
+		{name: "not breached", policy: Policy{Breached: &fakeBreaches{passwords: []string{"hunter2"}}}, password: "hunter3"},
+		{
+			name:      "every violation is listed",
+			policy:    Policy{MinLength: 12, MinCharacterClasses: 3, BannedWords: []string{"pass"}, MinStrength: 3},
+			password:  "password",
+			wantRules: []string{RuleMinLength, RuleCharacterClasses, RuleBannedWord, RuleStrength},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password, tt.userInputs...)
+			if len(tt.wantRules) == 0 {
+				if err != nil {
+					t.Fatalf("Check() error = %v", err)
+				}
+				return
+			}
+
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("Check() error = %v, want a *PolicyError", err)
+			}
+			rules := make([]string, 0, len(policyErr.Violations))
+			for _, violation := range policyErr.Violations {
+				rules = append(rules, violation.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Errorf("Check() violations = %v, want %v", rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestPolicyCheckBreachError(t *testing.T) {
+	policy := Policy{Breached: &fakeBreaches{err: errors.New("disk failure")}}
+
+	err := policy.Check("password")
+	var policyErr *PolicyError
+	if err == nil || errors.As(err, &policyErr) {
+		t.Errorf("Check() error = %v, want a failure that is not a policy violation", err)
+	}
+}
+
+func TestStrength(t *testing.T) {
+	tests := []struct {
+		name       string
+		password   string
+		userInputs []string
+		min, max   int
+	}{
+		{name: "empty", password: "", min: 0, max: 0},
+		{name: "common password", password: "password", min: 0, max: 0},
+		{name: "common password with substitutions", password: "p@ssw0rd", min: 0, max: 1},
+		{name: "capitalized common password", password: "Password", min: 0, max: 1},
+		{name: "repeated character", password: "aaaaaaaaaa", min: 0, max: 1},
+		{name: "sequence", password: "abcdefgh", min: 0, max: 1},
+		{name: "keyboard walk", password: "qwertyuiop", min: 0, max: 1},
+		{name: "user input", password: "janedoe1985", userInputs: []string{"janedoe"}, min: 0, max: 2},
+		{name: "random characters", password: "vT9#qLw2!zRm", min: 3, max: 4},
+		{name: "long passphrase", password: "correct-horse-battery-staple-cloud", min: 4, max: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Strength(tt.password, tt.userInputs...); got < tt.min || got > tt.max {
+				t.Errorf("Strength() = %d, want %d to %d", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func TestBreachedPasswords(t *testing.T) {
+	breached := sha1Hex("hunter2")
+	padding := sha1Hex("padding")
+
+	dir := t.TempDir()
+	rangeFile := breached[5:] + ":17\r\n" + padding[5:] + ":0\n"
+	if err := os.WriteFile(filepath.Join(dir, breached[:5]+".txt"), []byte(rangeFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, padding[:5]+".txt"), []byte(padding[5:]+":0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "hashes.txt")
+	hashes := strings.ToLower(breached) + ":17\n\n" + padding + ":0\n"
+	if err := os.WriteFile(file, []byte(hashes), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{dir, file} {
+		dataset, err := LoadBreachedPasswords(path)
+		if err != nil {
+			t.Fatalf("LoadBreachedPasswords(%s) error = %v", path, err)
+		}
+
+		tests := []struct {
+			password string
+			want     bool
+		}{
+			{password: "hunter2", want: true},
+			{password: "hunter3"},
+			{password: "padding"},
+		}
+		for _, tt := range tests {
+			t.Run(filepath.Base(path)+"/"+tt.password, func(t *testing.T) {
+				got, err := dataset.Breached(tt.password)
+				if err != nil || got != tt.want {
+					t.Errorf("Breached() = %v, %v, want %v", got, err, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestLoadBreachedPasswordsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{name: "not a hash", contents: "hunter2\n"},
+		{name: "truncated hash", contents: sha1Hex("hunter2")[:39] + ":1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "hashes.txt")
+			if err := os.WriteFile(file, []byte(tt.contents), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadBreachedPasswords(file); err == nil {
+				t.Error("LoadBreachedPasswords() accepted an invalid dataset")
+			}
+		})
+	}
+
+	if _, err := LoadBreachedPasswords(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("LoadBreachedPasswords() accepted a missing path")
+	}
+}
@@ -0,0 +1,255 @@
+package password
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// commonPasswords are the most used passwords and password words, most common first. Passwords
+// made of them are guessed early, so they are scored by their rank rather than their length.
+var commonPasswords = strings.Fields(`
+	password 123456 qwerty admin letmein welcome monkey dragon football baseball iloveyou
+	master sunshine princess shadow superman batman trustno1 starwars login passw0rd
+	abc123 111111 000000 123123 654321 696969 121212 secret freedom whatever qazwsx
+	michael jessica charlie jordan hunter ranger buster soccer hockey killer george
+	andrew thomas daniel robert jennifer ashley summer winter spring autumn flower
+	computer internet google apple orange banana cheese chocolate cookie pepper ginger
+	maggie tigger mustang harley yankees dallas chelsea arsenal liverpool london
+	hello hello123 love lovely angel angels friend friends family forever happy
+	matrix access secure security changeme default guest root test tester user
+	money silver golden diamond hammer thunder lightning phoenix falcon eagle
+	purple yellow blue green black white red pink naruto pokemon minecraft
+	jesus christ god blessed heaven
+`)
+
+// keyboardRows are walked by passwords like "qwerty" and "asdfgh"
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// leetSubstitutions undoes common character substitutions such as "p@ssw0rd"
+var leetSubstitutions = map[rune]rune{
+	'4': 'a', '@': 'a', '3': 'e', '1': 'i', '!': 'i', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't',
+}
+
+// Strength scores how hard password is to guess from 0 (trivial) to 4 (very hard), in the manner
+// of zxcvbn: the password is split into the cheapest sequence of common passwords, userInputs,
+// repeats, sequences, keyboard walks, years and random characters, and the estimated number of
+// guesses needed is mapped to a score.
+func Strength(password string, userInputs ...string) int {
+	guesses := log10Guesses(password, userInputs)
+	switch {
+	case guesses < 3:
+		return 0
+	case guesses < 6:
+		return 1
+	case guesses < 8:
+		return 2
+	case guesses < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// match is a part of a password, runes[start:end], that costs log10 guesses to find
+type match struct {
+	start, end int
+	cost       float64
+}
+
+// log10Guesses estimates the base-10 logarithm of the number of guesses needed to find password
+func log10Guesses(password string, userInputs []string) float64 {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	lower := make([]rune, len(runes))
+	normalized := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+		normalized[i] = lower[i]
+		if sub, ok := leetSubstitutions[lower[i]]; ok {
+			normalized[i] = sub
+		}
+	}
+
+	var matches []match
+	matches = append(matches, dictionaryMatches(runes, lower, normalized, userInputs)...)
+	matches = append(matches, repeatMatches(lower)...)
+	matches = append(matches, sequenceMatches(lower)...)
+	matches = append(matches, keyboardMatches(lower)...)
+	matches = append(matches, yearMatches(runes)...)
+
+	// best[i] is the cheapest way to guess the first i runes
+	bruteForce := math.Log10(float64(cardinality(runes)))
+	best := make([]float64, len(runes)+1)
+	for end := 1; end <= len(runes); end++ {
+		best[end] = best[end-1] + bruteForce
+		for _, m := range matches {
+			if m.end == end && best[m.start]+m.cost < best[end] {
+				best[end] = best[m.start] + m.cost
+			}
+		}
+	}
+
+	return best[len(runes)]
+}
+
+// dictionaryMatches finds common passwords and user inputs, also when capitalized or written
+// with substitutions
+func dictionaryMatches(runes, lower, normalized []rune, userInputs []string) []match {
+	var matches []match
+	find := func(word string, rank int) {
+		target := []rune(word)
+		for start := 0; start+len(target) <= len(runes); start++ {
+			if !equalRunes(normalized[start:start+len(target)], target) && !equalRunes(lower[start:start+len(target)], target) {
+				continue
+			}
+
+			end := start + len(target)
+			cost := math.Log10(float64(rank + 1))
+			if !equalRunes(runes[start:end], lower[start:end]) {
+				cost++ // capitalization
+			}
+			if !equalRunes(lower[start:end], target) {
+				cost++ // substitutions
+			}
+			matches = append(matches, match{start: start, end: end, cost: cost})
+		}
+	}
+
+	for rank, word := range commonPasswords {
+		find(word, rank)
+	}
+	for _, word := range userInputs {
+		if len(word) >= minBannedWordLength {
+			find(strings.ToLower(word), 0)
+		}
+	}
+
+	return matches
+}
+
+// repeatMatches finds runs of the same character, like "aaaa"
+func repeatMatches(lower []rune) []match {
+	var matches []match
+	for start := 0; start < len(lower); {
+		end := start + 1
+		for end < len(lower) && lower[end] == lower[start] {
+			end++
+		}
+		if end-start >= 3 {
+			matches = append(matches, match{start: start, end: end, cost: math.Log10(float64(10 * (end - start)))})
+		}
+		start = end
+	}
+	return matches
+}
+
+// sequenceMatches finds runs of consecutive characters, like "abcd" or "9876"
+func sequenceMatches(lower []rune) []match {
+	var matches []match
+	for start := 0; start+2 < len(lower); {
+		delta := lower[start+1] - lower[start]
+		end := start + 1
+		if delta == 1 || delta == -1 {
+			for end < len(lower) && lower[end]-lower[end-1] == delta {
+				end++
+			}
+		}
+		if end-start >= 3 {
+			matches = append(matches, match{start: start, end: end, cost: math.Log10(float64(26 * (end - start)))})
+			start = end
+			continue
+		}
+		start++
+	}
+	return matches
+}
+
+// keyboardMatches finds walks along a keyboard row, like "qwerty" or "lkjh"
+func keyboardMatches(lower []rune) []match {
+	var matches []match
+	for _, row := range keyboardRows {
+		for _, walk := range []string{row, reverse(row)} {
+			for start := 0; start+2 < len(lower); start++ {
+				pos := strings.IndexRune(walk, lower[start])
+				if pos < 0 {
+					continue
+				}
+
+				end := start + 1
+				for end < len(lower) && pos+end-start < len(walk) && rune(walk[pos+end-start]) == lower[end] {
+					end++
+				}
+				if end-start >= 3 {
+					matches = append(matches, match{start: start, end: end, cost: math.Log10(float64(40 * (end - start)))})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// yearMatches finds recent years, like "1987" or "2024"
+func yearMatches(runes []rune) []match {
+	var matches []match
+	for start := 0; start+4 <= len(runes); start++ {
+		year := string(runes[start : start+4])
+		if (strings.HasPrefix(year, "19") || strings.HasPrefix(year, "20")) && strings.Trim(year, "0123456789") == "" {
+			matches = append(matches, match{start: start, end: start + 4, cost: math.Log10(120)})
+		}
+	}
+	return matches
+}
+
+// cardinality is the number of characters a brute-force search for password has to try per position
+func cardinality(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.present {
+			size += class.size
+		}
+	}
+	return size
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ErrAPIKeyNotFound is returned when no unrevoked API key matches
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+type APIKey struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"`
+	Scopes     []string   `json:"scopes" db:"scopes"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	LastUsedIP string     `json:"last_used_ip,omitempty" db:"last_used_ip"`
+}
+
+type APIKeyRepository struct {
+	DB *database.DB
+}
+
+func NewAPIKeyRepository(db *database.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		DB: db,
+	}
+}
+
+// Create stores a new API key
+func (r *APIKeyRepository) Create(ctx context.Context, key *APIKey) error {
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+
+	key.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.DB.ExecContext(ctx, query,
+		key.ID, key.UserID, key.Name, key.Prefix, key.KeyHash, pq.Array(key.Scopes), key.CreatedAt, key.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByPrefix retrieves an unrevoked API key by its prefix, whether or not it has expired
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*APIKey, error) {
+	query := `
+		SELECT id, user_id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, COALESCE(last_used_ip, '')
+		FROM api_keys
+		WHERE prefix = $1 AND revoked_at IS NULL
+	`
+
+	key, err := scanAPIKey(r.DB.QueryRowContext(ctx, query, prefix))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListByUser retrieves the unrevoked API keys of a user, including expired ones, oldest first
+func (r *APIKeyRepository) ListByUser(ctx context.Context, userID string) ([]*APIKey, error) {
+	query := `
+		SELECT id, user_id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, COALESCE(last_used_ip, '')
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes an API key of a user
+func (r *APIKeyRepository) Revoke(ctx context.Context, userID, id string) error {
+	query := `UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := r.DB.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records that an API key was used from ipAddress
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id, ipAddress string, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2, last_used_ip = NULLIF($3, '') WHERE id = $1`
+
+	if _, err := r.DB.ExecContext(ctx, query, id, usedAt, ipAddress); err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*APIKey, error) {
+	key := &APIKey{}
+
+	err := row.Scan(
+		&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.KeyHash, pq.Array(&key.Scopes),
+		&key.CreatedAt, &key.ExpiresAt, &key.LastUsedAt, &key.LastUsedIP,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+)
+
+type AuditEvent struct {
+	ID        string            `json:"id" db:"id"`
+	EventType string            `json:"event_type" db:"event_type"`
+	Outcome   string            `json:"outcome" db:"outcome"`
+	ActorID   string            `json:"actor_id" db:"actor_id"`
+	SubjectID string            `json:"subject_id" db:"subject_id"`
+	IPAddress string            `json:"ip_address" db:"ip_address"`
+	UserAgent string            `json:"user_agent" db:"user_agent"`
+	RequestID string            `json:"request_id" db:"request_id"`
+	Details   map[string]string `json:"details" db:"details"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+// AuditFilter selects audit events; empty fields match everything
+type AuditFilter struct {
+	EventType string
+	Outcome   string
+	ActorID   string
+	SubjectID string
+	From      time.Time
+	To        time.Time
+}
+
+type AuditRepository struct {
+	DB *database.DB
+}
+
+func NewAuditRepository(db *database.DB) *AuditRepository {
+	return &AuditRepository{
+		DB: db,
+	}
+}
+
+// Create appends an event to the audit log
+func (r *AuditRepository) Create(ctx context.Context, event *AuditEvent) error {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	event.CreatedAt = time.Now()
+
+	details := event.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event details: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_events (id, event_type, outcome, actor_id, subject_id, ip_address, user_agent, request_id, details, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9, $10)
+	`
+
+	_, err = r.DB.ExecContext(ctx, query,
+		event.ID, event.EventType, event.Outcome, event.ActorID, event.SubjectID,
+		event.IPAddress, event.UserAgent, event.RequestID, detailsJSON, event.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves matching events, newest first, along with the total number of matches
+func (r *AuditRepository) List(ctx context.Context, filter AuditFilter, limit, offset int) ([]*AuditEvent, int, error) {
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.EventType != "" {
+		where("event_type = $%d", filter.EventType)
+	}
+	if filter.Outcome != "" {
+		where("outcome = $%d", filter.Outcome)
+	}
+	if filter.ActorID != "" {
+		where("actor_id = $%d", filter.ActorID)
+	}
+	if filter.SubjectID != "" {
+		where("subject_id = $%d", filter.SubjectID)
+	}
+	if !filter.From.IsZero() {
+		where("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("created_at < $%d", filter.To)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM audit_events ` + whereClause
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, event_type, outcome, COALESCE(actor_id, ''), COALESCE(subject_id, ''), COALESCE(ip_address, ''),
+		       COALESCE(user_agent, ''), COALESCE(request_id, ''), details, created_at
+		FROM audit_events
+		%s
+		ORDER BY created_at DESC, id
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// ListByUser retrieves every event a user acted in or was the subject of, oldest first
+func (r *AuditRepository) ListByUser(ctx context.Context, userID string) ([]*AuditEvent, error) {
+	query := `
+		SELECT id, event_type, outcome, COALESCE(actor_id, ''), COALESCE(subject_id, ''), COALESCE(ip_address, ''),
+		       COALESCE(user_agent, ''), COALESCE(request_id, ''), details, created_at
+		FROM audit_events
+		WHERE actor_id = $1 OR subject_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, nil
+}
+
+func scanAuditEvent(rows *sql.Rows) (*AuditEvent, error) {
+	event := &AuditEvent{}
+	var details []byte
+
+	err := rows.Scan(
+		&event.ID, &event.EventType, &event.Outcome, &event.ActorID, &event.SubjectID, &event.IPAddress,
+		&event.UserAgent, &event.RequestID, &details, &event.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(details, &event.Details); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/lib/pq"
+)
+
+type OAuthClient struct {
+	ID           string    `json:"id" db:"id"`
+	SecretHash   string    `json:"-" db:"secret_hash"`
+	Name         string    `json:"name" db:"name"`
+	RedirectURIs []string  `json:"redirect_uris" db:"redirect_uris"`
+	Scopes       []string  `json:"scopes" db:"scopes"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	Active       bool      `json:"active" db:"active"`
+}
+
+// Public reports whether the client has no secret and must rely on PKCE alone
+func (c *OAuthClient) Public() bool {
+	return c.SecretHash == ""
+}
+
+type ClientRepository struct {
+	DB *database.DB
+}
+
+func NewClientRepository(db *database.DB) *ClientRepository {
+	return &ClientRepository{
+		DB: db,
+	}
+}
+
+// GetByID retrieves an active client by ID
+func (r *ClientRepository) GetByID(ctx context.Context, id string) (*OAuthClient, error) {
+	client := &OAuthClient{}
+	query := `
+		SELECT id, COALESCE(secret_hash, ''), name, redirect_uris, scopes, created_at, updated_at, active
+		FROM oauth_clients
+		WHERE id = $1 AND active = true
+	`
+
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(
+		&client.ID, &client.SecretHash, &client.Name, pq.Array(&client.RedirectURIs), pq.Array(&client.Scopes),
+		&client.CreatedAt, &client.UpdatedAt, &client.Active,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("client not found")
+		}
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	return client, nil
+}
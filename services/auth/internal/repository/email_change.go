@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ErrEmailChangeTokenNotFound is returned when no unused, unexpired email change token matches
+var ErrEmailChangeTokenNotFound = errors.New("email change token not found")
+
+type EmailChangeToken struct {
+	ID        string     `json:"id" db:"id"`
+	UserID    string     `json:"user_id" db:"user_id"`
+	NewEmail  string     `json:"new_email" db:"new_email"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+}
+
+// EmailChange is a confirmed change of a user's email address
+type EmailChange struct {
+	UserID   string
+	OldEmail string
+	NewEmail string
+}
+
+type EmailChangeRepository struct {
+	DB *database.DB
+}
+
+func NewEmailChangeRepository(db *database.DB) *EmailChangeRepository {
+	return &EmailChangeRepository{
+		DB: db,
+	}
+}
+
+// Create stores a new email change token, invalidating any change the user requested earlier
+func (r *EmailChangeRepository) Create(ctx context.Context, token *EmailChangeToken) error {
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+
+	token.CreatedAt = time.Now()
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `UPDATE email_change_tokens SET used_at = $2 WHERE user_id = $1 AND used_at IS NULL`
+		if _, err := tx.ExecContext(ctx, query, token.UserID, token.CreatedAt); err != nil {
+			return fmt.Errorf("failed to invalidate email change tokens: %w", err)
+		}
+
+		query = `
+			INSERT INTO email_change_tokens (id, user_id, new_email, token_hash, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`
+		if _, err := tx.ExecContext(ctx, query, token.ID, token.UserID, token.NewEmail, token.TokenHash, token.CreatedAt, token.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to create email change token: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Consume marks the token with tokenHash as used and moves its user to the new, verified email
+// address in a single transaction
+func (r *EmailChangeRepository) Consume(ctx context.Context, tokenHash string) (*EmailChange, error) {
+	change := &EmailChange{}
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			UPDATE email_change_tokens
+			SET used_at = CURRENT_TIMESTAMP
+			WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+			RETURNING user_id, new_email
+		`
+		if err := tx.QueryRowContext(ctx, query, tokenHash).Scan(&change.UserID, &change.NewEmail); err != nil {
+			if err == sql.ErrNoRows {
+				return ErrEmailChangeTokenNotFound
+			}
+			return fmt.Errorf("failed to consume email change token: %w", err)
+		}
+
+		query = `SELECT email FROM users WHERE id = $1 AND active = true FOR UPDATE`
+		if err := tx.QueryRowContext(ctx, query, change.UserID).Scan(&change.OldEmail); err != nil {
+			if err == sql.ErrNoRows {
+				return ErrEmailChangeTokenNotFound
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		query = `UPDATE users SET email = $2, email_verified = true, updated_at = $3 WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, query, change.UserID, change.NewEmail, time.Now()); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+				return ErrEmailTaken
+			}
+			return fmt.Errorf("failed to change email: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+)
+
+// ErrVerificationTokenNotFound is returned when no unused, unexpired verification token matches
+var ErrVerificationTokenNotFound = errors.New("email verification token not found")
+
+type EmailVerificationToken struct {
+	ID        string     `json:"id" db:"id"`
+	UserID    string     `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+}
+
+type EmailVerificationRepository struct {
+	DB *database.DB
+}
+
+func NewEmailVerificationRepository(db *database.DB) *EmailVerificationRepository {
+	return &EmailVerificationRepository{
+		DB: db,
+	}
+}
+
+// Create stores a new verification token, invalidating any token sent to the user earlier
+func (r *EmailVerificationRepository) Create(ctx context.Context, token *EmailVerificationToken) error {
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+
+	token.CreatedAt = time.Now()
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `UPDATE email_verification_tokens SET used_at = $2 WHERE user_id = $1 AND used_at IS NULL`
+		if _, err := tx.ExecContext(ctx, query, token.UserID, token.CreatedAt); err != nil {
+			return fmt.Errorf("failed to invalidate verification tokens: %w", err)
+		}
+
+		query = `
+			INSERT INTO email_verification_tokens (id, user_id, token_hash, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		if _, err := tx.ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash, token.CreatedAt, token.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to create verification token: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// LastSentAt returns when the most recent verification token of a user was created, or the zero
+// time if none was
+func (r *EmailVerificationRepository) LastSentAt(ctx context.Context, userID string) (time.Time, error) {
+	var sentAt sql.NullTime
+	query := `SELECT MAX(created_at) FROM email_verification_tokens WHERE user_id = $1`
+
+	if err := r.DB.QueryRowContext(ctx, query, userID).Scan(&sentAt); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last verification token: %w", err)
+	}
+
+	return sentAt.Time, nil
+}
+
+// Consume marks the token with tokenHash as used and the email of its user as verified in a
+// single transaction, returning the user ID
+func (r *EmailVerificationRepository) Consume(ctx context.Context, tokenHash string) (string, error) {
+	var userID string
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			UPDATE email_verification_tokens
+			SET used_at = CURRENT_TIMESTAMP
+			WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+			RETURNING user_id
+		`
+		if err := tx.QueryRowContext(ctx, query, tokenHash).Scan(&userID); err != nil {
+			if err == sql.ErrNoRows {
+				return ErrVerificationTokenNotFound
+			}
+			return fmt.Errorf("failed to consume verification token: %w", err)
+		}
+
+		query = `UPDATE users SET email_verified = true, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return userID, nil
+}
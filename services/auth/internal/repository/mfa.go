@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+)
+
+// ErrTOTPNotFound is returned when a user has no TOTP secret
+var ErrTOTPNotFound = errors.New("TOTP secret not found")
+
+type TOTPSecret struct {
+	UserID       string     `json:"user_id" db:"user_id"`
+	Secret       string     `json:"-" db:"secret"`
+	LastUsedStep int64      `json:"-" db:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty" db:"confirmed_at"`
+}
+
+// Confirmed reports whether the user proved they can generate codes, which turns MFA on
+func (t *TOTPSecret) Confirmed() bool {
+	return t.ConfirmedAt != nil
+}
+
+type MFARepository struct {
+	DB *database.DB
+}
+
+func NewMFARepository(db *database.DB) *MFARepository {
+	return &MFARepository{
+		DB: db,
+	}
+}
+
+// GetTOTP retrieves the TOTP secret of a user, confirmed or not
+func (r *MFARepository) GetTOTP(ctx context.Context, userID string) (*TOTPSecret, error) {
+	secret := &TOTPSecret{}
+	query := `
+		SELECT user_id, secret, last_used_step, created_at, confirmed_at
+		FROM user_totp
+		WHERE user_id = $1
+	`
+
+	err := r.DB.QueryRowContext(ctx, query, userID).Scan(
+		&secret.UserID, &secret.Secret, &secret.LastUsedStep, &secret.CreatedAt, &secret.ConfirmedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTOTPNotFound
+		}
+		return nil, fmt.Errorf("failed to get TOTP secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+// SaveTOTP stores a new unconfirmed secret for a user, replacing an earlier unconfirmed one.
+// A confirmed secret is never replaced; it reports false in that case.
+func (r *MFARepository) SaveTOTP(ctx context.Context, userID, secret string) (bool, error) {
+	query := `
+		INSERT INTO user_totp (user_id, secret, created_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = EXCLUDED.created_at
+		WHERE user_totp.confirmed_at IS NULL
+	`
+
+	result, err := r.DB.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return false, fmt.Errorf("failed to save TOTP secret: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ConfirmTOTP turns on the TOTP secret of a user, recording step as used, and replaces the
+// user's recovery codes with codeHashes in a single transaction
+func (r *MFARepository) ConfirmTOTP(ctx context.Context, userID string, step int64, codeHashes []string) error {
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			UPDATE user_totp
+			SET confirmed_at = CURRENT_TIMESTAMP, last_used_step = $2
+			WHERE user_id = $1 AND confirmed_at IS NULL
+		`
+		result, err := tx.ExecContext(ctx, query, userID, step)
+		if err != nil {
+			return fmt.Errorf("failed to confirm TOTP secret: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return ErrTOTPNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+
+		query = `INSERT INTO mfa_recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)`
+		for _, hash := range codeHashes {
+			if _, err := tx.ExecContext(ctx, query, uuid.New().String(), userID, hash); err != nil {
+				return fmt.Errorf("failed to create recovery code: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// UseTOTPStep records step as the last accepted time step of a confirmed secret. It reports false
+// if the step (or a later one) was already used, so a code cannot be replayed.
+func (r *MFARepository) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	query := `
+		UPDATE user_totp
+		SET last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2
+	`
+
+	result, err := r.DB.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to record TOTP step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// UseRecoveryCode marks an unused recovery code as used, reporting whether one matched
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.DB.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Delete removes the TOTP secret and recovery codes of a user, turning MFA off
+func (r *MFARepository) Delete(ctx context.Context, userID string) error {
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to delete TOTP secret: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,586 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrOrganizationNotFound is returned when no organization matches
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrOrganizationSlugTaken is returned when another organization already uses a slug
+	ErrOrganizationSlugTaken = errors.New("organization slug already taken")
+	// ErrMemberNotFound is returned when a user is not a member of an organization
+	ErrMemberNotFound = errors.New("organization member not found")
+	// ErrAlreadyMember is returned when a user joins an organization they are a member of
+	ErrAlreadyMember = errors.New("already a member of the organization")
+	// ErrLastOwner is returned when a change would leave an organization without an owner
+	ErrLastOwner = errors.New("organization must keep at least one owner")
+	// ErrInvitationNotFound is returned when no pending, unexpired invitation matches
+	ErrInvitationNotFound = errors.New("organization invitation not found")
+)
+
+// Invitation statuses
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+)
+
+// orgRoleOwner is the organization role that cannot be removed from the last member holding it
+const orgRoleOwner = "owner"
+
+type Organization struct {
+	ID        string    `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Slug      string    `json:"slug" db:"slug"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// OrganizationMembership is an organization together with a user's role in it
+type OrganizationMembership struct {
+	Organization
+	Role     string    `json:"role" db:"role"`
+	JoinedAt time.Time `json:"joined_at" db:"created_at"`
+}
+
+// OrganizationMember is a member of an organization with their user details
+type OrganizationMember struct {
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	UserID         string    `json:"user_id" db:"user_id"`
+	Email          string    `json:"email" db:"email"`
+	FirstName      string    `json:"first_name" db:"first_name"`
+	LastName       string    `json:"last_name" db:"last_name"`
+	Role           string    `json:"role" db:"role"`
+	JoinedAt       time.Time `json:"joined_at" db:"created_at"`
+}
+
+type OrganizationInvitation struct {
+	ID             string     `json:"id" db:"id"`
+	OrganizationID string     `json:"organization_id" db:"organization_id"`
+	Email          string     `json:"email" db:"email"`
+	Role           string     `json:"role" db:"role"`
+	TokenHash      string     `json:"-" db:"token_hash"`
+	InvitedBy      string     `json:"invited_by,omitempty" db:"invited_by"`
+	Status         string     `json:"status" db:"status"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty" db:"responded_at"`
+}
+
+type OrganizationRepository struct {
+	DB *database.DB
+}
+
+func NewOrganizationRepository(db *database.DB) *OrganizationRepository {
+	return &OrganizationRepository{
+		DB: db,
+	}
+}
+
+// Create stores a new organization with ownerID as its first owner
+func (r *OrganizationRepository) Create(ctx context.Context, org *Organization, ownerID string) error {
+	if org.ID == "" {
+		org.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	org.CreatedAt = now
+	org.UpdatedAt = now
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO organizations (id, name, slug, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		if _, err := tx.ExecContext(ctx, query, org.ID, org.Name, org.Slug, org.CreatedAt, org.UpdatedAt); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+				return ErrOrganizationSlugTaken
+			}
+			return fmt.Errorf("failed to create organization: %w", err)
+		}
+
+		query = `INSERT INTO organization_members (organization_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.ExecContext(ctx, query, org.ID, ownerID, orgRoleOwner, now); err != nil {
+			return fmt.Errorf("failed to add organization owner: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// GetByID retrieves an organization by ID
+func (r *OrganizationRepository) GetByID(ctx context.Context, id string) (*Organization, error) {
+	query := `SELECT id, name, slug, created_at, updated_at FROM organizations WHERE id = $1`
+
+	org := &Organization{}
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&org.ID, &org.Name, &org.Slug, &org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// Delete deletes an organization with its memberships and invitations
+func (r *OrganizationRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM organizations WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+// ListByUser retrieves the organizations a user is a member of, in the order they joined
+func (r *OrganizationRepository) ListByUser(ctx context.Context, userID string) ([]*OrganizationMembership, error) {
+	query := `
+		SELECT o.id, o.name, o.slug, o.created_at, o.updated_at, m.role, m.created_at
+		FROM organization_members m
+		JOIN organizations o ON o.id = m.organization_id
+		WHERE m.user_id = $1
+		ORDER BY m.created_at, o.id
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	memberships := []*OrganizationMembership{}
+	for rows.Next() {
+		membership := &OrganizationMembership{}
+		err := rows.Scan(
+			&membership.ID, &membership.Name, &membership.Slug, &membership.CreatedAt, &membership.UpdatedAt,
+			&membership.Role, &membership.JoinedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		memberships = append(memberships, membership)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return memberships, nil
+}
+
+// GetMember retrieves a user's membership of an organization
+func (r *OrganizationRepository) GetMember(ctx context.Context, orgID, userID string) (*OrganizationMember, error) {
+	query := `
+		SELECT m.organization_id, m.user_id, u.email, u.first_name, u.last_name, m.role, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1 AND m.user_id = $2
+	`
+
+	member, err := scanOrganizationMember(r.DB.QueryRowContext(ctx, query, orgID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+
+	return member, nil
+}
+
+// ListMembers retrieves the members of an organization, in the order they joined
+func (r *OrganizationRepository) ListMembers(ctx context.Context, orgID string) ([]*OrganizationMember, error) {
+	query := `
+		SELECT m.organization_id, m.user_id, u.email, u.first_name, u.last_name, m.role, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1
+		ORDER BY m.created_at, m.user_id
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*OrganizationMember{}
+	for rows.Next() {
+		member, err := scanOrganizationMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+
+	return members, nil
+}
+
+// HasMemberWithEmail reports whether a user with email is a member of an organization
+func (r *OrganizationRepository) HasMemberWithEmail(ctx context.Context, orgID, email string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM organization_members m
+			JOIN users u ON u.id = m.user_id
+			WHERE m.organization_id = $1 AND LOWER(u.email) = LOWER($2)
+		)
+	`
+
+	var exists bool
+	if err := r.DB.QueryRowContext(ctx, query, orgID, email).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check organization membership: %w", err)
+	}
+
+	return exists, nil
+}
+
+// UpdateMemberRole changes the role of a member, failing with ErrLastOwner if that would leave the
+// organization without an owner
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID, role string) error {
+	return r.DB.Transaction(func(tx *sql.Tx) error {
+		if role != orgRoleOwner {
+			if err := checkNotLastOwner(ctx, tx, orgID, userID); err != nil {
+				return err
+			}
+		}
+
+		query := `UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2`
+		result, err := tx.ExecContext(ctx, query, orgID, userID, role)
+		if err != nil {
+			return fmt.Errorf("failed to update organization member: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return ErrMemberNotFound
+		}
+
+		return nil
+	})
+}
+
+// RemoveMember removes a member from an organization, failing with ErrLastOwner if they are its
+// only owner
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	return r.DB.Transaction(func(tx *sql.Tx) error {
+		if err := checkNotLastOwner(ctx, tx, orgID, userID); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+		result, err := tx.ExecContext(ctx, query, orgID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to remove organization member: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return ErrMemberNotFound
+		}
+
+		return nil
+	})
+}
+
+// HandOverOrganizations prepares the organizations of a user for the user's deletion: those
+// where they are the only member are deleted, and in those where they are the only owner the
+// longest-standing admin, or else member, becomes owner
+func (r *OrganizationRepository) HandOverOrganizations(ctx context.Context, userID string) error {
+	return r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			DELETE FROM organizations o
+			WHERE EXISTS (SELECT 1 FROM organization_members m WHERE m.organization_id = o.id AND m.user_id = $1)
+			AND NOT EXISTS (SELECT 1 FROM organization_members m WHERE m.organization_id = o.id AND m.user_id <> $1)
+		`
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			return fmt.Errorf("failed to delete organizations: %w", err)
+		}
+
+		query = `
+			SELECT organization_id FROM organization_members
+			WHERE organization_id IN (SELECT organization_id FROM organization_members WHERE user_id = $1 AND role = $2)
+			AND role = $2
+			GROUP BY organization_id
+			HAVING COUNT(*) = 1
+		`
+		rows, err := tx.QueryContext(ctx, query, userID, orgRoleOwner)
+		if err != nil {
+			return fmt.Errorf("failed to find owned organizations: %w", err)
+		}
+		var orgIDs []string
+		for rows.Next() {
+			var orgID string
+			if err := rows.Scan(&orgID); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan organization: %w", err)
+			}
+			orgIDs = append(orgIDs, orgID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to find owned organizations: %w", err)
+		}
+
+		query = `
+			UPDATE organization_members SET role = $3
+			WHERE organization_id = $1 AND user_id = (
+				SELECT user_id FROM organization_members
+				WHERE organization_id = $1 AND user_id <> $2
+				ORDER BY role = 'admin' DESC, created_at, user_id
+				LIMIT 1
+			)
+		`
+		for _, orgID := range orgIDs {
+			if _, err := tx.ExecContext(ctx, query, orgID, userID, orgRoleOwner); err != nil {
+				return fmt.Errorf("failed to hand over organization: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// CreateInvitation stores a new invitation, replacing any pending invitation of the same address
+// to the organization
+func (r *OrganizationRepository) CreateInvitation(ctx context.Context, invitation *OrganizationInvitation) error {
+	if invitation.ID == "" {
+		invitation.ID = uuid.New().String()
+	}
+
+	invitation.Status = InvitationPending
+	invitation.CreatedAt = time.Now()
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			UPDATE organization_invitations SET status = $3, responded_at = $4
+			WHERE organization_id = $1 AND LOWER(email) = LOWER($2) AND status = $5
+		`
+		if _, err := tx.ExecContext(ctx, query, invitation.OrganizationID, invitation.Email, InvitationRevoked, invitation.CreatedAt, InvitationPending); err != nil {
+			return fmt.Errorf("failed to replace organization invitation: %w", err)
+		}
+
+		query = `
+			INSERT INTO organization_invitations (id, organization_id, email, role, token_hash, invited_by, status, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9)
+		`
+		_, err := tx.ExecContext(ctx, query,
+			invitation.ID, invitation.OrganizationID, invitation.Email, invitation.Role, invitation.TokenHash,
+			invitation.InvitedBy, invitation.Status, invitation.CreatedAt, invitation.ExpiresAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create organization invitation: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// GetInvitationByTokenHash retrieves a pending, unexpired invitation by the hash of its token
+func (r *OrganizationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*OrganizationInvitation, error) {
+	query := `
+		SELECT id, organization_id, email, role, token_hash, COALESCE(invited_by, ''), status, created_at, expires_at, responded_at
+		FROM organization_invitations
+		WHERE token_hash = $1 AND status = $2 AND expires_at > CURRENT_TIMESTAMP
+	`
+
+	invitation, err := scanOrganizationInvitation(r.DB.QueryRowContext(ctx, query, tokenHash, InvitationPending))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// ListPendingInvitations retrieves the pending, unexpired invitations of an organization, newest first
+func (r *OrganizationRepository) ListPendingInvitations(ctx context.Context, orgID string) ([]*OrganizationInvitation, error) {
+	query := `
+		SELECT id, organization_id, email, role, token_hash, COALESCE(invited_by, ''), status, created_at, expires_at, responded_at
+		FROM organization_invitations
+		WHERE organization_id = $1 AND status = $2 AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, orgID, InvitationPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []*OrganizationInvitation{}
+	for rows.Next() {
+		invitation, err := scanOrganizationInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization invitation: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// RespondToInvitation moves a pending invitation of an organization to status
+func (r *OrganizationRepository) RespondToInvitation(ctx context.Context, orgID, id, status string) error {
+	query := `
+		UPDATE organization_invitations SET status = $3, responded_at = CURRENT_TIMESTAMP
+		WHERE organization_id = $1 AND id = $2 AND status = $4
+	`
+
+	result, err := r.DB.ExecContext(ctx, query, orgID, id, status, InvitationPending)
+	if err != nil {
+		return fmt.Errorf("failed to update organization invitation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+// AcceptInvitation marks a pending invitation as accepted and adds userID to its organization
+// with the invited role in a single transaction
+func (r *OrganizationRepository) AcceptInvitation(ctx context.Context, invitation *OrganizationInvitation, userID string) error {
+	return r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			UPDATE organization_invitations SET status = $2, responded_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND status = $3 AND expires_at > CURRENT_TIMESTAMP
+		`
+		result, err := tx.ExecContext(ctx, query, invitation.ID, InvitationAccepted, InvitationPending)
+		if err != nil {
+			return fmt.Errorf("failed to accept organization invitation: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return ErrInvitationNotFound
+		}
+
+		query = `INSERT INTO organization_members (organization_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.ExecContext(ctx, query, invitation.OrganizationID, userID, invitation.Role, time.Now()); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+				return ErrAlreadyMember
+			}
+			return fmt.Errorf("failed to add organization member: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// checkNotLastOwner fails with ErrLastOwner if userID is the only owner of an organization. The
+// owners stay locked until the transaction ends, so concurrent changes cannot remove them all.
+func checkNotLastOwner(ctx context.Context, tx *sql.Tx, orgID, userID string) error {
+	query := `SELECT user_id FROM organization_members WHERE organization_id = $1 AND role = $2 FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, query, orgID, orgRoleOwner)
+	if err != nil {
+		return fmt.Errorf("failed to get organization owners: %w", err)
+	}
+	defer rows.Close()
+
+	owner, others := false, 0
+	for rows.Next() {
+		var ownerID string
+		if err := rows.Scan(&ownerID); err != nil {
+			return fmt.Errorf("failed to scan organization owner: %w", err)
+		}
+		if ownerID == userID {
+			owner = true
+		} else {
+			others++
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to get organization owners: %w", err)
+	}
+
+	if owner && others == 0 {
+		return ErrLastOwner
+	}
+
+	return nil
+}
+
+func scanOrganizationMember(row rowScanner) (*OrganizationMember, error) {
+	member := &OrganizationMember{}
+
+	err := row.Scan(
+		&member.OrganizationID, &member.UserID, &member.Email, &member.FirstName, &member.LastName,
+		&member.Role, &member.JoinedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func scanOrganizationInvitation(row rowScanner) (*OrganizationInvitation, error) {
+	invitation := &OrganizationInvitation{}
+
+	err := row.Scan(
+		&invitation.ID, &invitation.OrganizationID, &invitation.Email, &invitation.Role, &invitation.TokenHash,
+		&invitation.InvitedBy, &invitation.Status, &invitation.CreatedAt, &invitation.ExpiresAt, &invitation.RespondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+)
+
+// ErrResetTokenNotFound is returned when no unused, unexpired reset token matches
+var ErrResetTokenNotFound = errors.New("password reset token not found")
+
+type PasswordResetToken struct {
+	ID        string     `json:"id" db:"id"`
+	UserID    string     `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+}
+
+type PasswordResetRepository struct {
+	DB *database.DB
+}
+
+func NewPasswordResetRepository(db *database.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{
+		DB: db,
+	}
+}
+
+// Create stores a new reset token, invalidating any token the user requested earlier
+func (r *PasswordResetRepository) Create(ctx context.Context, token *PasswordResetToken) error {
+	if token.ID == "" {
+		token.ID = uuid.New().String()
+	}
+
+	token.CreatedAt = time.Now()
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `UPDATE password_reset_tokens SET used_at = $2 WHERE user_id = $1 AND used_at IS NULL`
+		if _, err := tx.ExecContext(ctx, query, token.UserID, token.CreatedAt); err != nil {
+			return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+		}
+
+		query = `
+			INSERT INTO password_reset_tokens (id, user_id, token_hash, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		if _, err := tx.ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash, token.CreatedAt, token.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to create reset token: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// GetUserID returns the user of the unused, unexpired reset token with tokenHash
+func (r *PasswordResetRepository) GetUserID(ctx context.Context, tokenHash string) (string, error) {
+	query := `
+		SELECT user_id FROM password_reset_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+	`
+
+	var userID string
+	if err := r.DB.QueryRowContext(ctx, query, tokenHash).Scan(&userID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrResetTokenNotFound
+		}
+		return "", fmt.Errorf("failed to get reset token: %w", err)
+	}
+
+	return userID, nil
+}
+
+// Consume marks the token with tokenHash as used and sets the password of its user in a single
+// transaction, returning the user ID. The token must be unused and unexpired.
+func (r *PasswordResetRepository) Consume(ctx context.Context, tokenHash, passwordHash string) (string, error) {
+	var userID string
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			UPDATE password_reset_tokens
+			SET used_at = CURRENT_TIMESTAMP
+			WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+			RETURNING user_id
+		`
+		if err := tx.QueryRowContext(ctx, query, tokenHash).Scan(&userID); err != nil {
+			if err == sql.ErrNoRows {
+				return ErrResetTokenNotFound
+			}
+			return fmt.Errorf("failed to consume reset token: %w", err)
+		}
+
+		query = `UPDATE users SET password = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND active = true`
+		result, err := tx.ExecContext(ctx, query, userID, passwordHash)
+		if err != nil {
+			return fmt.Errorf("failed to update password: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+
+		// Tokens of deactivated users are worthless
+		if rowsAffected == 0 {
+			return ErrResetTokenNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+)
+
+// ErrRoleNotAssigned is returned when removing a role the user does not have
+var ErrRoleNotAssigned = errors.New("role not assigned")
+
+type RoleRepository struct {
+	DB *database.DB
+}
+
+func NewRoleRepository(db *database.DB) *RoleRepository {
+	return &RoleRepository{
+		DB: db,
+	}
+}
+
+// GetUserRoles retrieves the role names assigned to a user
+func (r *RoleRepository) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		SELECT role_name
+		FROM user_roles
+		WHERE user_id = $1
+		ORDER BY role_name
+	`
+
+	return r.queryNames(ctx, query, userID)
+}
+
+// GetUserPermissions retrieves the permissions granted to a user through all of their roles
+func (r *RoleRepository) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		SELECT DISTINCT rp.permission_name
+		FROM user_roles ur
+		JOIN role_permissions rp ON rp.role_name = ur.role_name
+		WHERE ur.user_id = $1
+		ORDER BY rp.permission_name
+	`
+
+	return r.queryNames(ctx, query, userID)
+}
+
+// RoleExists checks if a role is defined
+func (r *RoleRepository) RoleExists(ctx context.Context, role string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM roles WHERE name = $1)`
+
+	err := r.DB.QueryRowContext(ctx, query, role).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check role existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// PermissionExists checks if a permission is defined
+func (r *RoleRepository) PermissionExists(ctx context.Context, permission string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM permissions WHERE name = $1)`
+
+	err := r.DB.QueryRowContext(ctx, query, permission).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// AssignRole grants a role to a user; assigning a role twice is a no-op
+func (r *RoleRepository) AssignRole(ctx context.Context, userID, role string) error {
+	query := `
+		INSERT INTO user_roles (user_id, role_name)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := r.DB.ExecContext(ctx, query, userID, role); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveRole revokes a role from a user
+func (r *RoleRepository) RemoveRole(ctx context.Context, userID, role string) error {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND role_name = $2`
+
+	result, err := r.DB.ExecContext(ctx, query, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to remove role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrRoleNotAssigned
+	}
+
+	return nil
+}
+
+func (r *RoleRepository) queryNames(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query names: %w", err)
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan name: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query names: %w", err)
+	}
+
+	return names, nil
+}
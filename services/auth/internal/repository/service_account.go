@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ErrServiceAccountNotFound is returned when no active service account matches
+var ErrServiceAccountNotFound = errors.New("service account not found")
+
+type ServiceAccount struct {
+	ID         string    `json:"id" db:"id"`
+	ClientID   string    `json:"client_id" db:"client_id"`
+	SecretHash string    `json:"-" db:"secret_hash"`
+	Name       string    `json:"name" db:"name"`
+	Scopes     []string  `json:"scopes" db:"scopes"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	Active     bool      `json:"active" db:"active"`
+}
+
+type ServiceAccountRepository struct {
+	DB *database.DB
+}
+
+func NewServiceAccountRepository(db *database.DB) *ServiceAccountRepository {
+	return &ServiceAccountRepository{
+		DB: db,
+	}
+}
+
+// Create creates a new service account
+func (r *ServiceAccountRepository) Create(ctx context.Context, account *ServiceAccount) error {
+	if account.ID == "" {
+		account.ID = uuid.New().String()
+	}
+
+	account.CreatedAt = time.Now()
+	account.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO service_accounts (id, client_id, secret_hash, name, scopes, created_at, updated_at, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.DB.ExecContext(ctx, query,
+		account.ID, account.ClientID, account.SecretHash, account.Name, pq.Array(account.Scopes),
+		account.CreatedAt, account.UpdatedAt, account.Active,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an active service account by ID
+func (r *ServiceAccountRepository) GetByID(ctx context.Context, id string) (*ServiceAccount, error) {
+	return r.getBy(ctx, "id", id)
+}
+
+// GetByClientID retrieves an active service account by client ID
+func (r *ServiceAccountRepository) GetByClientID(ctx context.Context, clientID string) (*ServiceAccount, error) {
+	return r.getBy(ctx, "client_id", clientID)
+}
+
+// List retrieves all active service accounts
+func (r *ServiceAccountRepository) List(ctx context.Context) ([]*ServiceAccount, error) {
+	query := `
+		SELECT id, client_id, secret_hash, name, scopes, created_at, updated_at, active
+		FROM service_accounts
+		WHERE active = true
+		ORDER BY created_at
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := []*ServiceAccount{}
+	for rows.Next() {
+		account := &ServiceAccount{}
+		if err := rows.Scan(
+			&account.ID, &account.ClientID, &account.SecretHash, &account.Name, pq.Array(&account.Scopes),
+			&account.CreatedAt, &account.UpdatedAt, &account.Active,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan service account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// UpdateSecret replaces the secret hash of an active service account
+func (r *ServiceAccountRepository) UpdateSecret(ctx context.Context, id, secretHash string) error {
+	query := `
+		UPDATE service_accounts
+		SET secret_hash = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND active = true
+	`
+
+	return r.execAffectingOne(ctx, query, id, secretHash)
+}
+
+// Delete soft deletes a service account (sets active = false)
+func (r *ServiceAccountRepository) Delete(ctx context.Context, id string) error {
+	query := `UPDATE service_accounts SET active = false, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND active = true`
+
+	return r.execAffectingOne(ctx, query, id)
+}
+
+func (r *ServiceAccountRepository) getBy(ctx context.Context, column, value string) (*ServiceAccount, error) {
+	account := &ServiceAccount{}
+	query := fmt.Sprintf(`
+		SELECT id, client_id, secret_hash, name, scopes, created_at, updated_at, active
+		FROM service_accounts
+		WHERE %s = $1 AND active = true
+	`, column)
+
+	err := r.DB.QueryRowContext(ctx, query, value).Scan(
+		&account.ID, &account.ClientID, &account.SecretHash, &account.Name, pq.Array(&account.Scopes),
+		&account.CreatedAt, &account.UpdatedAt, &account.Active,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return account, nil
+}
+
+func (r *ServiceAccountRepository) execAffectingOne(ctx context.Context, query string, args ...interface{}) error {
+	result, err := r.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update service account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrServiceAccountNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/google/uuid"
+)
+
+// ErrSessionNotFound is returned when no matching session exists
+var ErrSessionNotFound = errors.New("session not found")
+
+type Session struct {
+	ID             string     `json:"id" db:"id"`
+	UserID         string     `json:"user_id" db:"user_id"`
+	RefreshTokenID string     `json:"refresh_token_id" db:"refresh_token_id"`
+	DeviceName     string     `json:"device_name" db:"device_name"`
+	UserAgent      string     `json:"user_agent" db:"user_agent"`
+	IPAddress      string     `json:"ip_address" db:"ip_address"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	LastSeenAt     time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Active reports whether the session can still be used to refresh tokens
+func (s *Session) Active() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+type SessionRepository struct {
+	DB *database.DB
+}
+
+func NewSessionRepository(db *database.DB) *SessionRepository {
+	return &SessionRepository{
+		DB: db,
+	}
+}
+
+// Create creates a new session
+func (r *SessionRepository) Create(ctx context.Context, session *Session) error {
+	if session.ID == "" {
+		session.ID = uuid.New().String()
+	}
+
+	session.CreatedAt = time.Now()
+	session.LastSeenAt = session.CreatedAt
+
+	query := `
+		INSERT INTO sessions (id, user_id, refresh_token_id, device_name, user_agent, ip_address, created_at, last_seen_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.DB.ExecContext(ctx, query,
+		session.ID, session.UserID, session.RefreshTokenID, session.DeviceName, session.UserAgent,
+		session.IPAddress, session.CreatedAt, session.LastSeenAt, session.ExpiresAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a session by ID, including revoked and expired ones
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*Session, error) {
+	session := &Session{}
+	query := `
+		SELECT id, user_id, refresh_token_id, COALESCE(device_name, ''), COALESCE(user_agent, ''),
+		       COALESCE(ip_address, ''), created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE id = $1
+	`
+
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(
+		&session.ID, &session.UserID, &session.RefreshTokenID, &session.DeviceName, &session.UserAgent,
+		&session.IPAddress, &session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt, &session.RevokedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// ListActiveByUser retrieves the non-revoked, non-expired sessions of a user, most recently used first
+func (r *SessionRepository) ListActiveByUser(ctx context.Context, userID string) ([]*Session, error) {
+	query := `
+		SELECT id, user_id, refresh_token_id, COALESCE(device_name, ''), COALESCE(user_agent, ''),
+		       COALESCE(ip_address, ''), created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*Session
+	for rows.Next() {
+		session := &Session{}
+		if err := rows.Scan(
+			&session.ID, &session.UserID, &session.RefreshTokenID, &session.DeviceName, &session.UserAgent,
+			&session.IPAddress, &session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt, &session.RevokedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RotateRefreshToken replaces the session's refresh token ID only if it still equals oldTokenID.
+// It returns false when the session was revoked or the token was already rotated.
+func (r *SessionRepository) RotateRefreshToken(ctx context.Context, session *Session, oldTokenID string) (bool, error) {
+	session.LastSeenAt = time.Now()
+
+	query := `
+		UPDATE sessions
+		SET refresh_token_id = $3, ip_address = $4, user_agent = $5, last_seen_at = $6, expires_at = $7
+		WHERE id = $1 AND refresh_token_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.DB.ExecContext(ctx, query,
+		session.ID, oldTokenID, session.RefreshTokenID, session.IPAddress, session.UserAgent,
+		session.LastSeenAt, session.ExpiresAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate session token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// Revoke revokes a single session of a user
+func (r *SessionRepository) Revoke(ctx context.Context, userID, id string) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.DB.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllByUser revokes every active session of a user except exceptID and returns the revoked IDs
+func (r *SessionRepository) RevokeAllByUser(ctx context.Context, userID, exceptID string) ([]string, error) {
+	query := `
+		UPDATE sessions
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+		RETURNING id
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, userID, exceptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan session id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return ids, nil
+}
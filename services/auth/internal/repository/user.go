@@ -3,22 +3,53 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// ErrUserNotFound is returned when no matching user exists
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrEmailTaken is returned when an email address belongs to another user
+var ErrEmailTaken = errors.New("email address is already in use")
+
+// uniqueViolation is the PostgreSQL error code for unique constraint violations
+const uniqueViolation = "23505"
+
+// ErrUserModified is returned when a user changed since it was read
+var ErrUserModified = errors.New("user was modified concurrently")
+
+// ErrDeletionNotScheduled is returned when a user has no deletion that can be cancelled
+var ErrDeletionNotScheduled = errors.New("user deletion not scheduled")
+
+// ErrInvalidUserSort is returned when users are sorted by an unknown field
+var ErrInvalidUserSort = errors.New("invalid user sort field")
+
+// userSortColumns maps the fields users can be sorted by to their columns
+var userSortColumns = map[string]string{
+	"created_at": "u.created_at",
+	"updated_at": "u.updated_at",
+	"email":      "u.email",
+	"first_name": "u.first_name",
+	"last_name":  "u.last_name",
+}
+
 type User struct {
-	ID        string    `json:"id" db:"id"`
-	Email     string    `json:"email" db:"email"`
-	Password  string    `json:"-" db:"password"`
-	FirstName string    `json:"first_name" db:"first_name"`
-	LastName  string    `json:"last_name" db:"last_name"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
-	Active    bool      `json:"active" db:"active"`
+	ID            string    `json:"id" db:"id"`
+	Email         string    `json:"email" db:"email"`
+	Password      string    `json:"-" db:"password"`
+	FirstName     string    `json:"first_name" db:"first_name"`
+	LastName      string    `json:"last_name" db:"last_name"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	Active        bool      `json:"active" db:"active"`
+	EmailVerified bool      `json:"email_verified" db:"email_verified"`
 }
 
 type UserRepository struct {
@@ -41,13 +72,13 @@ func (r *UserRepository) Create(ctx context.Context, user *User) error {
 	user.UpdatedAt = time.Now()
 
 	query := `
-		INSERT INTO users (id, email, password, first_name, last_name, created_at, updated_at, active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO users (id, email, password, first_name, last_name, created_at, updated_at, active, email_verified)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	_, err := r.DB.ExecContext(ctx, query,
 		user.ID, user.Email, user.Password, user.FirstName, user.LastName,
-		user.CreatedAt, user.UpdatedAt, user.Active,
+		user.CreatedAt, user.UpdatedAt, user.Active, user.EmailVerified,
 	)
 
 	if err != nil {
@@ -57,23 +88,57 @@ func (r *UserRepository) Create(ctx context.Context, user *User) error {
 	return nil
 }
 
+// CreateWithRole creates a new user and grants it role in a single transaction
+func (r *UserRepository) CreateWithRole(ctx context.Context, user *User, role string) error {
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	err := r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO users (id, email, password, first_name, last_name, created_at, updated_at, active, email_verified)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
+
+		_, err := tx.ExecContext(ctx, query,
+			user.ID, user.Email, user.Password, user.FirstName, user.LastName,
+			user.CreatedAt, user.UpdatedAt, user.Active, user.EmailVerified,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		query = `INSERT INTO user_roles (user_id, role_name) VALUES ($1, $2)`
+		if _, err := tx.ExecContext(ctx, query, user.ID, role); err != nil {
+			return fmt.Errorf("failed to assign role: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
 // GetByEmail retrieves a user by email
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
 	user := &User{}
 	query := `
-		SELECT id, email, password, first_name, last_name, created_at, updated_at, active
+		SELECT id, email, password, first_name, last_name, created_at, updated_at, active, email_verified
 		FROM users 
 		WHERE email = $1 AND active = true
 	`
 
 	err := r.DB.QueryRowContext(ctx, query, email).Scan(
 		&user.ID, &user.Email, &user.Password, &user.FirstName, &user.LastName,
-		&user.CreatedAt, &user.UpdatedAt, &user.Active,
+		&user.CreatedAt, &user.UpdatedAt, &user.Active, &user.EmailVerified,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -85,19 +150,19 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*User, e
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*User, error) {
 	user := &User{}
 	query := `
-		SELECT id, email, password, first_name, last_name, created_at, updated_at, active
+		SELECT id, email, password, first_name, last_name, created_at, updated_at, active, email_verified
 		FROM users 
 		WHERE id = $1 AND active = true
 	`
 
 	err := r.DB.QueryRowContext(ctx, query, id).Scan(
 		&user.ID, &user.Email, &user.Password, &user.FirstName, &user.LastName,
-		&user.CreatedAt, &user.UpdatedAt, &user.Active,
+		&user.CreatedAt, &user.UpdatedAt, &user.Active, &user.EmailVerified,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -105,18 +170,183 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*User, error)
 	return user, nil
 }
 
-// Update updates a user
-func (r *UserRepository) Update(ctx context.Context, user *User) error {
-	user.UpdatedAt = time.Now()
+// UserFilter selects users; empty fields match everyone
+type UserFilter struct {
+	Search string // case-insensitive match on email, first or last name
+	Email  string
+	Roles  []string // users with any of these roles
+	Active *bool
+}
 
+// UserSort orders a user listing by one of userSortColumns
+type UserSort struct {
+	Field      string
+	Descending bool
+}
+
+// GetByIDIncludingInactive retrieves a user by ID, whether or not the account is active
+func (r *UserRepository) GetByIDIncludingInactive(ctx context.Context, id string) (*User, error) {
+	user := &User{}
 	query := `
-		UPDATE users 
-		SET first_name = $2, last_name = $3, updated_at = $4
+		SELECT id, email, password, first_name, last_name, created_at, updated_at, active, email_verified
+		FROM users
 		WHERE id = $1
 	`
 
-	result, err := r.DB.ExecContext(ctx, query, user.ID, user.FirstName, user.LastName, user.UpdatedAt)
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(
+		&user.ID, &user.Email, &user.Password, &user.FirstName, &user.LastName,
+		&user.CreatedAt, &user.UpdatedAt, &user.Active, &user.EmailVerified,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// List retrieves matching users, active or not, along with the total number of matches
+func (r *UserRepository) List(ctx context.Context, filter UserFilter, sorts []UserSort, limit, offset int) ([]*User, int, error) {
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Search != "" {
+		where("(u.email ILIKE $%[1]d OR u.first_name ILIKE $%[1]d OR u.last_name ILIKE $%[1]d)", "%"+escapeLike(filter.Search)+"%")
+	}
+	if filter.Email != "" {
+		where("u.email = $%d", filter.Email)
+	}
+	if len(filter.Roles) > 0 {
+		where("EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = u.id AND ur.role_name = ANY($%d))", pq.Array(filter.Roles))
+	}
+	if filter.Active != nil {
+		where("u.active = $%d", *filter.Active)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var orderBy []string
+	for _, sort := range sorts {
+		column, ok := userSortColumns[sort.Field]
+		if !ok {
+			return nil, 0, ErrInvalidUserSort
+		}
+		if sort.Descending {
+			column += " DESC"
+		}
+		orderBy = append(orderBy, column)
+	}
+	if len(orderBy) == 0 {
+		orderBy = append(orderBy, "u.created_at DESC")
+	}
+	orderBy = append(orderBy, "u.id")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users u ` + whereClause
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT u.id, u.email, u.password, u.first_name, u.last_name, u.created_at, u.updated_at, u.active, u.email_verified
+		FROM users u
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, whereClause, strings.Join(orderBy, ", "), len(args)+1, len(args)+2)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		user := &User{}
+		err := rows.Scan(
+			&user.ID, &user.Email, &user.Password, &user.FirstName, &user.LastName,
+			&user.CreatedAt, &user.UpdatedAt, &user.Active, &user.EmailVerified,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// Update updates a user's email, name and email verification status. user.UpdatedAt must be the
+// value last read; ErrUserModified is returned if the user was changed since.
+func (r *UserRepository) Update(ctx context.Context, user *User) error {
+	updatedAt := time.Now().Truncate(time.Microsecond)
+
+	query := `
+		UPDATE users
+		SET email = $2, first_name = $3, last_name = $4, email_verified = $5, updated_at = $6
+		WHERE id = $1 AND updated_at = $7
+	`
+
+	err := r.updateUnmodified(ctx, user.ID, query,
+		user.ID, user.Email, user.FirstName, user.LastName, user.EmailVerified, updatedAt, user.UpdatedAt,
+	)
 	if err != nil {
+		return err
+	}
+
+	user.UpdatedAt = updatedAt
+	return nil
+}
+
+// UpdatePassword replaces the password hash of a user. unmodifiedSince must be the updated_at value
+// last read; ErrUserModified is returned if the user was changed since.
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID, passwordHash string, unmodifiedSince time.Time) error {
+	query := `
+		UPDATE users
+		SET password = $2, updated_at = $3
+		WHERE id = $1 AND updated_at = $4
+	`
+
+	return r.updateUnmodified(ctx, userID, query, userID, passwordHash, time.Now().Truncate(time.Microsecond), unmodifiedSince)
+}
+
+// UpgradePasswordHash replaces a user's password hash with a new hash of the same password. It is
+// not a modification of the user, so updated_at is kept, and it does nothing if the password was
+// changed since oldHash was read.
+func (r *UserRepository) UpgradePasswordHash(ctx context.Context, userID, oldHash, newHash string) error {
+	query := `UPDATE users SET password = $3 WHERE id = $1 AND password = $2`
+
+	if _, err := r.DB.ExecContext(ctx, query, userID, oldHash, newHash); err != nil {
+		return fmt.Errorf("failed to upgrade password hash: %w", err)
+	}
+
+	return nil
+}
+
+// updateUnmodified runs an UPDATE of the user with id that is conditional on its updated_at value,
+// telling a missing user apart from a concurrent modification
+func (r *UserRepository) updateUnmodified(ctx context.Context, id, query string, args ...interface{}) error {
+	result, err := r.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return ErrEmailTaken
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -125,11 +355,19 @@ func (r *UserRepository) Update(ctx context.Context, user *User) error {
 		return fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("user not found")
+	if rowsAffected > 0 {
+		return nil
 	}
 
-	return nil
+	var exists bool
+	if err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check user existence: %w", err)
+	}
+	if !exists {
+		return ErrUserNotFound
+	}
+
+	return ErrUserModified
 }
 
 // Delete soft deletes a user (sets active = false)
@@ -147,12 +385,161 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("user not found")
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Reactivate restores a soft deleted user
+func (r *UserRepository) Reactivate(ctx context.Context, id string) error {
+	query := `UPDATE users SET active = true, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ScheduleDeletion schedules an active user for deletion at the given time
+func (r *UserRepository) ScheduleDeletion(ctx context.Context, id string, at time.Time) error {
+	query := `UPDATE users SET deletion_scheduled_at = $2 WHERE id = $1 AND active = true`
+
+	result, err := r.DB.ExecContext(ctx, query, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to schedule user deletion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
 	}
 
 	return nil
 }
 
+// CancelDeletion unschedules the deletion of a user. Deletions that are already due cannot be
+// cancelled; ErrDeletionNotScheduled is returned for them as for users without a deletion.
+func (r *UserRepository) CancelDeletion(ctx context.Context, id string) error {
+	query := `
+		UPDATE users
+		SET deletion_scheduled_at = NULL
+		WHERE id = $1 AND deletion_scheduled_at > CURRENT_TIMESTAMP
+	`
+
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to cancel user deletion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrDeletionNotScheduled
+	}
+
+	return nil
+}
+
+// GetDeletionSchedule returns when a user is scheduled to be deleted, or nil
+func (r *UserRepository) GetDeletionSchedule(ctx context.Context, id string) (*time.Time, error) {
+	var scheduledAt sql.NullTime
+	query := `SELECT deletion_scheduled_at FROM users WHERE id = $1`
+
+	if err := r.DB.QueryRowContext(ctx, query, id).Scan(&scheduledAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user deletion schedule: %w", err)
+	}
+
+	if !scheduledAt.Valid {
+		return nil, nil
+	}
+	return &scheduledAt.Time, nil
+}
+
+// ListDueForDeletion returns the IDs of up to limit users whose scheduled deletion is due, oldest first
+func (r *UserRepository) ListDueForDeletion(ctx context.Context, limit int) ([]string, error) {
+	query := `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at <= CURRENT_TIMESTAMP
+		ORDER BY deletion_scheduled_at
+		LIMIT $1
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users due for deletion: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// HardDelete irreversibly deletes a user whose scheduled deletion is due, together with everything
+// referencing it, and erases the client details of the audit events about the user. The events
+// themselves are kept. ErrUserNotFound is returned if the user is gone, is not due, or is being
+// deleted by another instance.
+func (r *UserRepository) HardDelete(ctx context.Context, id string) error {
+	return r.DB.Transaction(func(tx *sql.Tx) error {
+		query := `
+			SELECT id FROM users
+			WHERE id = $1 AND deletion_scheduled_at <= CURRENT_TIMESTAMP
+			FOR UPDATE SKIP LOCKED
+		`
+		if err := tx.QueryRowContext(ctx, query, id).Scan(&id); err != nil {
+			if err == sql.ErrNoRows {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to lock user: %w", err)
+		}
+
+		query = `
+			UPDATE audit_events
+			SET ip_address = NULL, user_agent = NULL
+			WHERE (actor_id = $1 OR subject_id = $1) AND (ip_address IS NOT NULL OR user_agent IS NOT NULL)
+		`
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return fmt.Errorf("failed to anonymize audit events: %w", err)
+		}
+
+		// Sessions, roles, tokens, MFA secrets and passkeys are deleted by cascade
+		if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id); err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+
+		return nil
+	})
+}
+
 // EmailExists checks if email already exists
 func (r *UserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
 	var exists bool
@@ -165,3 +552,8 @@ func (r *UserRepository) EmailExists(ctx context.Context, email string) (bool, e
 
 	return exists, nil
 }
+
+// escapeLike escapes the LIKE wildcards in s so it is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
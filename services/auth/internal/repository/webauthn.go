@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
+	"github.com/lib/pq"
+)
+
+// ErrCredentialNotFound is returned when no matching WebAuthn credential exists
+var ErrCredentialNotFound = errors.New("webauthn credential not found")
+
+type WebAuthnCredential struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	PublicKey  []byte     `json:"-" db:"public_key"`
+	SignCount  uint32     `json:"-" db:"sign_count"`
+	AAGUID     string     `json:"aaguid" db:"aaguid"`
+	Transports []string   `json:"transports" db:"transports"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+}
+
+type WebAuthnRepository struct {
+	DB *database.DB
+}
+
+func NewWebAuthnRepository(db *database.DB) *WebAuthnRepository {
+	return &WebAuthnRepository{
+		DB: db,
+	}
+}
+
+// Create stores a newly registered credential
+func (r *WebAuthnRepository) Create(ctx context.Context, credential *WebAuthnCredential) error {
+	credential.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO webauthn_credentials (id, user_id, name, public_key, sign_count, aaguid, transports, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.DB.ExecContext(ctx, query,
+		credential.ID, credential.UserID, credential.Name, credential.PublicKey, int64(credential.SignCount),
+		credential.AAGUID, pq.Array(credential.Transports), credential.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webauthn credential: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a credential by its base64url encoded credential ID
+func (r *WebAuthnRepository) GetByID(ctx context.Context, id string) (*WebAuthnCredential, error) {
+	query := `
+		SELECT id, user_id, name, public_key, sign_count, COALESCE(aaguid, ''), transports, created_at, last_used_at
+		FROM webauthn_credentials
+		WHERE id = $1
+	`
+
+	credential, err := scanWebAuthnCredential(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCredentialNotFound
+		}
+		return nil, fmt.Errorf("failed to get webauthn credential: %w", err)
+	}
+
+	return credential, nil
+}
+
+// ListByUser retrieves the credentials of a user, newest first
+func (r *WebAuthnRepository) ListByUser(ctx context.Context, userID string) ([]*WebAuthnCredential, error) {
+	query := `
+		SELECT id, user_id, name, public_key, sign_count, COALESCE(aaguid, ''), transports, created_at, last_used_at
+		FROM webauthn_credentials
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webauthn credentials: %w", err)
+	}
+	defer rows.Close()
+
+	var credentials []*WebAuthnCredential
+	for rows.Next() {
+		credential, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webauthn credential: %w", err)
+		}
+		credentials = append(credentials, credential)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webauthn credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// UpdateSignCount records a successful assertion and the authenticator's new signature counter
+func (r *WebAuthnRepository) UpdateSignCount(ctx context.Context, id string, signCount uint32) error {
+	query := `UPDATE webauthn_credentials SET sign_count = $2, last_used_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := r.DB.ExecContext(ctx, query, id, int64(signCount)); err != nil {
+		return fmt.Errorf("failed to update webauthn credential: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a credential of a user
+func (r *WebAuthnRepository) Delete(ctx context.Context, userID, id string) error {
+	query := `DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2`
+
+	result, err := r.DB.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webauthn credential: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrCredentialNotFound
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWebAuthnCredential(row rowScanner) (*WebAuthnCredential, error) {
+	credential := &WebAuthnCredential{}
+	var signCount int64
+
+	err := row.Scan(
+		&credential.ID, &credential.UserID, &credential.Name, &credential.PublicKey, &signCount,
+		&credential.AAGUID, pq.Array(&credential.Transports), &credential.CreatedAt, &credential.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	credential.SignCount = uint32(signCount)
+	return credential, nil
+}
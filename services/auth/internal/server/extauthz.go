@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/logger"
+	extauthzpb "github.com/VariableSan/go-factory-microservice/pkg/proto/extauthz"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/service"
+	"google.golang.org/grpc/codes"
+)
+
+// Headers injected into requests that Envoy forwards upstream after a successful check
+const (
+	headerUserID    = "x-user-id"
+	headerUserEmail = "x-user-email"
+	headerUserRoles = "x-user-roles"
+	// Upstream services keep tenants apart by the active organization of the token
+	headerOrgID   = "x-org-id"
+	headerOrgRole = "x-org-role"
+	// Set while an administrator impersonates the user, so upstream services can tell
+	headerActorID = "x-actor-id"
+)
+
+// identityHeaders are always stripped from incoming requests so clients cannot forge them
+var identityHeaders = []string{headerUserID, headerUserEmail, headerUserRoles, headerOrgID, headerOrgRole, headerActorID}
+
+// RoutePolicy decides which paths Envoy may forward without a bearer token. Entries ending in "*"
+// match by prefix, all others must match the path exactly. A route can also be made public from
+// the Envoy configuration with the context extension auth=public.
+type RoutePolicy struct {
+	PublicPaths []string
+}
+
+// Public reports whether path (with or without a query string) can be accessed anonymously.
+// Paths that are not in canonical form, with dot segments, repeated or escaped slashes, or
+// percent-encoding, are never public, as upstream services may resolve them to other routes.
+func (p RoutePolicy) Public(requestPath string) bool {
+	if i := strings.IndexAny(requestPath, "?#"); i >= 0 {
+		requestPath = requestPath[:i]
+	}
+	if !canonicalPath(requestPath) {
+		return false
+	}
+
+	for _, public := range p.PublicPaths {
+		if prefix, ok := strings.CutSuffix(public, "*"); ok {
+			if strings.HasPrefix(requestPath, prefix) {
+				return true
+			}
+		} else if requestPath == public {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalPath reports whether an absolute request path is unchanged by cleaning and decoding
+func canonicalPath(requestPath string) bool {
+	return strings.HasPrefix(requestPath, "/") &&
+		!strings.ContainsAny(requestPath, "%\\") &&
+		path.Clean(requestPath) == requestPath
+}
+
+// ExtAuthzServer implements Envoy's external authorization API, so the gateway authenticates
+// requests once at the edge instead of every service checking tokens itself
+type ExtAuthzServer struct {
+	extauthzpb.UnimplementedAuthorizationServer
+	authService *service.AuthService
+	policy      RoutePolicy
+	logger      *logger.Logger
+}
+
+func (s *ExtAuthzServer) Check(ctx context.Context, req *extauthzpb.CheckRequest) (*extauthzpb.CheckResponse, error) {
+	attributes := req.GetAttributes()
+	httpReq := attributes.GetRequest().GetHttp()
+
+	if httpReq.GetMethod() == "OPTIONS" || attributes.GetContextExtensions()["auth"] == "public" || s.policy.Public(httpReq.GetPath()) {
+		return allowed(nil), nil
+	}
+
+	// Personal API keys come in X-API-Key or with the ApiKey scheme, tokens with the Bearer scheme
+	token := httpReq.GetHeaders()["x-api-key"]
+	if token == "" {
+		token = httpReq.GetHeaders()["authorization"]
+		if len(token) > 7 && (strings.ToLower(token[:7]) == "bearer " || strings.ToLower(token[:7]) == "apikey ") {
+			token = token[7:]
+		}
+	}
+	if token == "" {
+		return denied("Authorization header required"), nil
+	}
+
+	user, err := s.authService.ValidateToken(ctx, token)
+	if err != nil {
+		s.logger.Debug("External authorization denied", "error", err, "path", httpReq.GetPath(), "request_id", httpReq.GetId())
+		return denied("Invalid token"), nil
+	}
+
+	headers := map[string]string{
+		headerUserID:    user.ID,
+		headerUserEmail: user.Email,
+		headerUserRoles: strings.Join(user.Roles, ","),
+	}
+	if user.OrganizationID != "" {
+		headers[headerOrgID] = user.OrganizationID
+		headers[headerOrgRole] = user.OrganizationRole
+	}
+	if user.ActorID != "" {
+		headers[headerActorID] = user.ActorID
+	}
+
+	return allowed(headers), nil
+}
+
+// allowed lets a request through, replacing any identity headers sent by the client with headers
+func allowed(headers map[string]string) *extauthzpb.CheckResponse {
+	ok := &extauthzpb.OkHttpResponse{}
+	for _, name := range identityHeaders {
+		if value, set := headers[name]; set {
+			ok.Headers = append(ok.Headers, &extauthzpb.HeaderValueOption{
+				Header:       &extauthzpb.HeaderValue{Key: name, Value: value},
+				AppendAction: extauthzpb.HeaderAppendAction_OVERWRITE_IF_EXISTS_OR_ADD,
+			})
+		} else {
+			ok.HeadersToRemove = append(ok.HeadersToRemove, name)
+		}
+	}
+
+	return &extauthzpb.CheckResponse{
+		Status:     &extauthzpb.Status{Code: int32(codes.OK)},
+		OkResponse: ok,
+	}
+}
+
+// denied rejects an unauthenticated request with a JSON body in the same envelope as the HTTP API
+func denied(message string) *extauthzpb.CheckResponse {
+	body, _ := json.Marshal(map[string]interface{}{
+		"success": false,
+		"error": map[string]string{
+			"code":    "UNAUTHORIZED",
+			"message": message,
+		},
+	})
+
+	return &extauthzpb.CheckResponse{
+		Status: &extauthzpb.Status{Code: int32(codes.Unauthenticated), Message: message},
+		DeniedResponse: &extauthzpb.DeniedHttpResponse{
+			Status: &extauthzpb.HttpStatus{Code: extauthzpb.StatusCode_Unauthorized},
+			Headers: []*extauthzpb.HeaderValueOption{{
+				Header: &extauthzpb.HeaderValue{Key: "content-type", Value: "application/json"},
+			}},
+			Body: string(body),
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package server
+
+import "testing"
+
+func TestRoutePolicyPublic(t *testing.T) {
+	policy := RoutePolicy{PublicPaths: []string{"/health", "/api/v1/auth/login", "/api/v1/auth/email/*", "/api/v1/auth/password/*"}}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/health", true},
+		{"/health?verbose=1", true},
+		{"/api/v1/auth/login", true},
+		{"/api/v1/auth/login/", false},
+		{"/api/v1/auth/email/verify", true},
+		{"/api/v1/auth/email/resend?x=1", true},
+		{"/api/v1/auth/me", false},
+		{"/api/v1/auth/email/../../v1/admin/users", false},
+		{"/api/v1/auth/email/./verify", false},
+		{"//api/v1/auth/password/%2e%2e/%2e%2e/v1/admin/users", false},
+		{"/api/v1/auth/password/%2e%2e/me", false},
+		{"/api/v1/auth/email//verify", false},
+		{"/api/v1/auth/email/..%2fme", false},
+		{"/api/v1/auth/email\\..\\me", false},
+		{"api/v1/auth/login", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Public(tt.path); got != tt.want {
+			t.Errorf("Public(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -2,16 +2,28 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/VariableSan/go-factory-microservice/pkg/common/logger"
+	"github.com/VariableSan/go-factory-microservice/pkg/common/ratelimit"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/tracing"
+	"github.com/VariableSan/go-factory-microservice/pkg/common/validation"
 	authpb "github.com/VariableSan/go-factory-microservice/pkg/proto/auth"
+	commonpb "github.com/VariableSan/go-factory-microservice/pkg/proto/common"
+	extauthzpb "github.com/VariableSan/go-factory-microservice/pkg/proto/extauthz"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/keys"
+	authMiddleware "github.com/VariableSan/go-factory-microservice/services/auth/internal/middleware"
 	"github.com/VariableSan/go-factory-microservice/services/auth/internal/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 type GRPCServer struct {
@@ -22,14 +34,110 @@ type GRPCServer struct {
 	tracingManager *tracing.TracingManager
 }
 
-func NewGRPCServer(authService *service.AuthService, port string, logger *logger.Logger, tracingManager *tracing.TracingManager) (*GRPCServer, error) {
+// methodPolicies lists the RPCs that require an authenticated caller. Service accounts are
+// checked against Scopes, so a method without scopes cannot be called by them.
+var methodPolicies = map[string]authMiddleware.MethodPolicy{
+	authpb.AuthService_ListSessions_FullMethodName:                  {},
+	authpb.AuthService_RevokeSession_FullMethodName:                 {},
+	authpb.AuthService_RevokeAllSessions_FullMethodName:             {},
+	authpb.AuthService_EnrollTOTP_FullMethodName:                    {},
+	authpb.AuthService_ConfirmTOTP_FullMethodName:                   {},
+	authpb.AuthService_DisableTOTP_FullMethodName:                   {},
+	authpb.AuthService_UpdateProfile_FullMethodName:                 {},
+	authpb.AuthService_ChangePassword_FullMethodName:                {},
+	authpb.AuthService_RequestEmailChange_FullMethodName:            {},
+	authpb.AuthService_ExportAccountData_FullMethodName:             {},
+	authpb.AuthService_RequestAccountDeletion_FullMethodName:        {},
+	authpb.AuthService_CancelAccountDeletion_FullMethodName:         {},
+	authpb.AuthService_CreateAPIKey_FullMethodName:                  {},
+	authpb.AuthService_ListAPIKeys_FullMethodName:                   {},
+	authpb.AuthService_RevokeAPIKey_FullMethodName:                  {},
+	authpb.AuthService_CreateOrganization_FullMethodName:            {},
+	authpb.AuthService_ListOrganizations_FullMethodName:             {},
+	authpb.AuthService_DeleteOrganization_FullMethodName:            {},
+	authpb.AuthService_ListOrganizationMembers_FullMethodName:       {},
+	authpb.AuthService_UpdateOrganizationMember_FullMethodName:      {},
+	authpb.AuthService_RemoveOrganizationMember_FullMethodName:      {},
+	authpb.AuthService_InviteOrganizationMember_FullMethodName:      {},
+	authpb.AuthService_ListOrganizationInvitations_FullMethodName:   {},
+	authpb.AuthService_RevokeOrganizationInvitation_FullMethodName:  {},
+	authpb.AuthService_AcceptOrganizationInvitation_FullMethodName:  {},
+	authpb.AuthService_DeclineOrganizationInvitation_FullMethodName: {},
+	authpb.AuthService_SwitchOrganization_FullMethodName:            {},
+	authpb.AuthService_AssignRole_FullMethodName: {
+		Permissions: []string{service.PermissionRolesManage},
+		Scopes:      []string{service.PermissionRolesManage},
+	},
+	authpb.AuthService_RemoveRole_FullMethodName: {
+		Permissions: []string{service.PermissionRolesManage},
+		Scopes:      []string{service.PermissionRolesManage},
+	},
+	authpb.AuthService_UnlockAccount_FullMethodName: {
+		Permissions: []string{service.PermissionUsersWrite},
+		Scopes:      []string{service.PermissionUsersWrite},
+	},
+	authpb.AuthService_ListAuditEvents_FullMethodName: {
+		Permissions: []string{service.PermissionAuditRead},
+		Scopes:      []string{service.PermissionAuditRead},
+	},
+	authpb.AuthService_ListUsers_FullMethodName:       {Roles: []string{service.RoleAdmin}},
+	authpb.AuthService_GetUser_FullMethodName:         {Roles: []string{service.RoleAdmin}},
+	authpb.AuthService_UpdateUser_FullMethodName:      {Roles: []string{service.RoleAdmin}},
+	authpb.AuthService_DeactivateUser_FullMethodName:  {Roles: []string{service.RoleAdmin}},
+	authpb.AuthService_ReactivateUser_FullMethodName:  {Roles: []string{service.RoleAdmin}},
+	authpb.AuthService_ForceLogout_FullMethodName:     {Roles: []string{service.RoleAdmin}},
+	authpb.AuthService_ResetMFA_FullMethodName:        {Roles: []string{service.RoleAdmin}},
+	authpb.AuthService_ImpersonateUser_FullMethodName: {Roles: []string{service.RoleAdmin}},
+	authpb.AuthService_CreateServiceAccount_FullMethodName:       {Permissions: []string{service.PermissionServiceAccountsManage}},
+	authpb.AuthService_ListServiceAccounts_FullMethodName:        {Permissions: []string{service.PermissionServiceAccountsManage}},
+	authpb.AuthService_RotateServiceAccountSecret_FullMethodName: {Permissions: []string{service.PermissionServiceAccountsManage}},
+	authpb.AuthService_DeleteServiceAccount_FullMethodName:       {Permissions: []string{service.PermissionServiceAccountsManage}},
+}
+
+// methodRules validates the requests of the RPCs that take user input, matching the validate
+// tags of the HTTP request bodies
+var methodRules = map[string]validation.Rules{
+	authpb.AuthService_Login_FullMethodName:                         {"email": "required,email", "password": "required"},
+	authpb.AuthService_Register_FullMethodName:                      {"email": "required,email", "password": "required", "first_name": "required", "last_name": "required"},
+	authpb.AuthService_VerifyMFA_FullMethodName:                     {"mfa_token": "required", "code": "required"},
+	authpb.AuthService_RefreshToken_FullMethodName:                  {"refresh_token": "required"},
+	authpb.AuthService_RequestPasswordReset_FullMethodName:          {"email": "required,email"},
+	authpb.AuthService_ResetPassword_FullMethodName:                 {"token": "required", "new_password": "required"},
+	authpb.AuthService_ConfirmTOTP_FullMethodName:                   {"code": "required"},
+	authpb.AuthService_DisableTOTP_FullMethodName:                   {"code": "required"},
+	authpb.AuthService_VerifyEmail_FullMethodName:                   {"token": "required"},
+	authpb.AuthService_ResendVerification_FullMethodName:            {"email": "required,email"},
+	authpb.AuthService_ChangePassword_FullMethodName:                {"current_password": "required", "new_password": "required"},
+	authpb.AuthService_RequestEmailChange_FullMethodName:            {"current_password": "required", "new_email": "required,email"},
+	authpb.AuthService_ConfirmEmailChange_FullMethodName:            {"token": "required"},
+	authpb.AuthService_RequestAccountDeletion_FullMethodName:        {"current_password": "required"},
+	authpb.AuthService_AssignRole_FullMethodName:                    {"user_id": "required", "role": "required"},
+	authpb.AuthService_RemoveRole_FullMethodName:                    {"user_id": "required", "role": "required"},
+	authpb.AuthService_UpdateUser_FullMethodName:                    {"user_id": "required", "email": "omitempty,email"},
+	authpb.AuthService_CreateServiceAccount_FullMethodName:          {"name": "required"},
+	authpb.AuthService_CreateAPIKey_FullMethodName:                  {"name": "required"},
+	authpb.AuthService_CreateOrganization_FullMethodName:            {"name": "required", "slug": "required"},
+	authpb.AuthService_UpdateOrganizationMember_FullMethodName:      {"organization_id": "required", "user_id": "required", "role": "required"},
+	authpb.AuthService_InviteOrganizationMember_FullMethodName:      {"organization_id": "required", "email": "required,email", "role": "required"},
+	authpb.AuthService_AcceptOrganizationInvitation_FullMethodName:  {"token": "required"},
+	authpb.AuthService_DeclineOrganizationInvitation_FullMethodName: {"token": "required"},
+}
+
+func NewGRPCServer(authService *service.AuthService, port string, keySet *keys.KeySet, trustedProxies authMiddleware.TrustedProxies, routePolicy RoutePolicy, limiter ratelimit.Limiter, logger *logger.Logger, tracingManager *tracing.TracingManager) (*GRPCServer, error) {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create gRPC server with middleware
-	server := grpc.NewServer()
+	interceptors := []grpc.UnaryServerInterceptor{authMiddleware.ClientInfoUnaryInterceptor(trustedProxies)}
+	if limiter != nil {
+		interceptors = append(interceptors, ratelimit.UnaryServerInterceptor(limiter, rateLimitKey))
+	}
+	interceptors = append(interceptors, authMiddleware.AuthUnaryInterceptor(keySet.Keyfunc, authService, methodPolicies))
+	interceptors = append(interceptors, validation.UnaryServerInterceptor(methodRules))
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
 
 	// Register auth service
 	authServer := &AuthGRPCServer{
@@ -38,9 +146,17 @@ func NewGRPCServer(authService *service.AuthService, port string, logger *logger
 	}
 	authpb.RegisterAuthServiceServer(server, authServer)
 
+	// Register Envoy external authorization for the gateway
+	extauthzpb.RegisterAuthorizationServer(server, &ExtAuthzServer{
+		authService: authService,
+		policy:      routePolicy,
+		logger:      logger.WithComponent("ext-authz"),
+	})
+
 	// Register health check service
 	healthServer := health.NewServer()
 	healthServer.SetServingStatus("auth.v1.AuthService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("envoy.service.auth.v3.Authorization", grpc_health_v1.HealthCheckResponse_SERVING)
 	grpc_health_v1.RegisterHealthServer(server, healthServer)
 
 	// Register reflection service for development
@@ -55,6 +171,15 @@ func NewGRPCServer(authService *service.AuthService, port string, logger *logger
 	}, nil
 }
 
+// rateLimitKey keys RPCs by client IP. Envoy's authorization checks and health checks are not
+// limited: they come from the gateway and the orchestrator on behalf of everybody.
+func rateLimitKey(ctx context.Context, info *grpc.UnaryServerInfo) string {
+	if strings.HasPrefix(info.FullMethod, "/envoy.") || strings.HasPrefix(info.FullMethod, "/grpc.health.") {
+		return ""
+	}
+	return authMiddleware.GRPCRateLimitKeyByClientIP(ctx, info)
+}
+
 func (s *GRPCServer) Start() error {
 	s.logger.Info("Starting gRPC server", "addr", s.listener.Addr())
 	return s.server.Serve(s.listener)
@@ -73,10 +198,13 @@ type AuthGRPCServer struct {
 }
 
 func (s *AuthGRPCServer) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.LoginResponse, error) {
-	s.logger.Info("Login request", "email", req.Email)
+	s.logger.Info("Login request")
 
-	user, token, refreshToken, err := s.authService.Login(ctx, req.Email, req.Password)
+	result, err := s.authService.Login(ctx, req.Email, req.Password)
 	if err != nil {
+		if err == service.ErrAccountLocked || err == service.ErrLoginThrottled {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
 		s.logger.Error("Login failed", "error", err)
 		return &authpb.LoginResponse{
 			Success: false,
@@ -84,17 +212,104 @@ func (s *AuthGRPCServer) Login(ctx context.Context, req *authpb.LoginRequest) (*
 		}, nil
 	}
 
+	if result.MFARequired() {
+		return &authpb.LoginResponse{
+			Success:     true,
+			MfaRequired: true,
+			MfaToken:    result.MFAToken,
+			Message:     "Two-factor authentication required",
+		}, nil
+	}
+
 	return &authpb.LoginResponse{
 		Success:      true,
-		Token:        token,
-		RefreshToken: refreshToken,
-		User:         convertToProtoUser(user),
+		Token:        result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		User:         convertToProtoUser(result.User),
+		Message:      "Login successful",
+	}, nil
+}
+
+func (s *AuthGRPCServer) VerifyMFA(ctx context.Context, req *authpb.VerifyMFARequest) (*authpb.VerifyMFAResponse, error) {
+	result, err := s.authService.VerifyMFA(ctx, req.MfaToken, req.Code)
+	if err != nil {
+		if err == service.ErrTooManyMFAAttempts || err == service.ErrAccountLocked {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if err == service.ErrMFAUnavailable {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		s.logger.Error("MFA verification failed", "error", err)
+		return &authpb.VerifyMFAResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.VerifyMFAResponse{
+		Success:      true,
+		Token:        result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		User:         convertToProtoUser(result.User),
 		Message:      "Login successful",
 	}, nil
 }
 
+func (s *AuthGRPCServer) EnrollTOTP(ctx context.Context, req *authpb.EnrollTOTPRequest) (*authpb.EnrollTOTPResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	enrollment, err := s.authService.EnrollTOTP(ctx, userID)
+	if err != nil {
+		return &authpb.EnrollTOTPResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.EnrollTOTPResponse{
+		Success: true,
+		Secret:  enrollment.Secret,
+		Uri:     enrollment.URI,
+		Message: "Scan the URI with an authenticator app and confirm with a code",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ConfirmTOTP(ctx context.Context, req *authpb.ConfirmTOTPRequest) (*authpb.ConfirmTOTPResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	recoveryCodes, err := s.authService.ConfirmTOTP(ctx, userID, req.Code)
+	if err != nil {
+		return &authpb.ConfirmTOTPResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ConfirmTOTPResponse{
+		Success:       true,
+		RecoveryCodes: recoveryCodes,
+		Message:       "Two-factor authentication enabled; store the recovery codes securely",
+	}, nil
+}
+
+func (s *AuthGRPCServer) DisableTOTP(ctx context.Context, req *authpb.DisableTOTPRequest) (*authpb.DisableTOTPResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.DisableTOTP(ctx, userID, req.Code); err != nil {
+		return &authpb.DisableTOTPResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.DisableTOTPResponse{
+		Success: true,
+		Message: "Two-factor authentication disabled",
+	}, nil
+}
+
 func (s *AuthGRPCServer) Register(ctx context.Context, req *authpb.RegisterRequest) (*authpb.RegisterResponse, error) {
-	s.logger.Info("Register request", "email", req.Email)
+	s.logger.Info("Register request")
 
 	user, err := s.authService.Register(ctx, req.Email, req.Password, req.FirstName, req.LastName)
 	if err != nil {
@@ -122,15 +337,107 @@ func (s *AuthGRPCServer) ValidateToken(ctx context.Context, req *authpb.Validate
 	}
 
 	return &authpb.ValidateTokenResponse{
-		Valid:   true,
-		User:    convertToProtoUser(user),
-		Roles:   []string{}, // No roles in simplified model
-		Message: "Token is valid",
+		Valid:            true,
+		User:             convertToProtoUser(user),
+		Roles:            user.Roles,
+		Message:          "Token is valid",
+		OrganizationId:   user.OrganizationID,
+		OrganizationRole: user.OrganizationRole,
+		ActorId:          user.ActorID,
+	}, nil
+}
+
+func (s *AuthGRPCServer) Logout(ctx context.Context, req *authpb.LogoutRequest) (*authpb.LogoutResponse, error) {
+	err := s.authService.Logout(ctx, req.Token)
+	if err == service.ErrRevocationUnavailable {
+		return &authpb.LogoutResponse{
+			Success: true,
+			Message: err.Error(),
+		}, nil
+	}
+	if err == service.ErrInvalidToken {
+		return &authpb.LogoutResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Logout failed", "error", err)
+		return &authpb.LogoutResponse{
+			Success: false,
+			Message: "Failed to log out",
+		}, nil
+	}
+
+	return &authpb.LogoutResponse{
+		Success: true,
+		Message: "Logout successful",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RequestPasswordReset(ctx context.Context, req *authpb.RequestPasswordResetRequest) (*authpb.RequestPasswordResetResponse, error) {
+	if err := s.authService.RequestPasswordReset(ctx, req.Email); err != nil {
+		s.logger.Error("Password reset request failed", "error", err)
+		return &authpb.RequestPasswordResetResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RequestPasswordResetResponse{
+		Success: true,
+		Message: "If the email is registered, a password reset link has been sent",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ResetPassword(ctx context.Context, req *authpb.ResetPasswordRequest) (*authpb.ResetPasswordResponse, error) {
+	if err := s.authService.ResetPassword(ctx, req.Token, req.NewPassword); err != nil {
+		return &authpb.ResetPasswordResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ResetPasswordResponse{
+		Success: true,
+		Message: "Password reset successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) VerifyEmail(ctx context.Context, req *authpb.VerifyEmailRequest) (*authpb.VerifyEmailResponse, error) {
+	if err := s.authService.VerifyEmail(ctx, req.Token); err != nil {
+		return &authpb.VerifyEmailResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.VerifyEmailResponse{
+		Success: true,
+		Message: "Email verified successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ResendVerification(ctx context.Context, req *authpb.ResendVerificationRequest) (*authpb.ResendVerificationResponse, error) {
+	if err := s.authService.ResendVerification(ctx, req.Email); err != nil {
+		if err == service.ErrVerificationThrottled {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		s.logger.Error("Resend verification failed", "error", err)
+		return &authpb.ResendVerificationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ResendVerificationResponse{
+		Success: true,
+		Message: "If the email is registered and unverified, a verification link has been sent",
 	}, nil
 }
 
 func (s *AuthGRPCServer) RefreshToken(ctx context.Context, req *authpb.RefreshTokenRequest) (*authpb.RefreshTokenResponse, error) {
-	newToken, err := s.authService.RefreshToken(ctx, req.RefreshToken)
+	newToken, newRefreshToken, err := s.authService.RefreshToken(ctx, req.RefreshToken)
 	if err != nil {
 		return &authpb.RefreshTokenResponse{
 			Success: false,
@@ -141,7 +448,7 @@ func (s *AuthGRPCServer) RefreshToken(ctx context.Context, req *authpb.RefreshTo
 	return &authpb.RefreshTokenResponse{
 		Success:      true,
 		Token:        newToken,
-		RefreshToken: req.RefreshToken, // Keep the same refresh token
+		RefreshToken: newRefreshToken,
 		Message:      "Token refreshed successfully",
 	}, nil
 }
@@ -162,19 +469,998 @@ func (s *AuthGRPCServer) GetUserProfile(ctx context.Context, req *authpb.GetUser
 	}, nil
 }
 
-func convertToProtoUser(user *service.User) *authpb.User {
-	if user == nil {
-		return nil
+func (s *AuthGRPCServer) UpdateProfile(ctx context.Context, req *authpb.UpdateProfileRequest) (*authpb.UpdateProfileResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	update := service.ProfileUpdate{FirstName: req.FirstName, LastName: req.LastName}
+	if req.UpdatedAt != 0 {
+		update.UpdatedAt = time.Unix(req.UpdatedAt, 0)
 	}
 
-	return &authpb.User{
-		Id:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Roles:     []string{}, // No roles in simplified model
-		CreatedAt: user.CreatedAt.Unix(),
-		UpdatedAt: user.UpdatedAt.Unix(),
-		Active:    user.Active,
+	user, err := s.authService.UpdateProfile(ctx, userID, update)
+	if err != nil {
+		return &authpb.UpdateProfileResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.UpdateProfileResponse{
+		Success: true,
+		User:    convertToProtoUser(user),
+		Message: "Profile updated successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ChangePassword(ctx context.Context, req *authpb.ChangePasswordRequest) (*authpb.ChangePasswordResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+	sessionID, _ := ctx.Value("sessionID").(string)
+
+	if err := s.authService.ChangePassword(ctx, userID, sessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		return &authpb.ChangePasswordResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ChangePasswordResponse{
+		Success: true,
+		Message: "Password changed successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RequestEmailChange(ctx context.Context, req *authpb.RequestEmailChangeRequest) (*authpb.RequestEmailChangeResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.RequestEmailChange(ctx, userID, req.CurrentPassword, req.NewEmail); err != nil {
+		return &authpb.RequestEmailChangeResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RequestEmailChangeResponse{
+		Success: true,
+		Message: "A confirmation link has been sent to the new email address",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ConfirmEmailChange(ctx context.Context, req *authpb.ConfirmEmailChangeRequest) (*authpb.ConfirmEmailChangeResponse, error) {
+	if err := s.authService.ConfirmEmailChange(ctx, req.Token); err != nil {
+		return &authpb.ConfirmEmailChangeResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ConfirmEmailChangeResponse{
+		Success: true,
+		Message: "Email address changed successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ExportAccountData(ctx context.Context, req *authpb.ExportAccountDataRequest) (*authpb.ExportAccountDataResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if req.Format != "" && req.Format != service.ExportFormatZip && req.Format != service.ExportFormatJSON {
+		return &authpb.ExportAccountDataResponse{
+			Success: false,
+			Message: service.ErrInvalidExportFormat.Error(),
+		}, nil
+	}
+
+	export, err := s.authService.ExportAccountData(ctx, userID)
+	if err != nil {
+		return &authpb.ExportAccountDataResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	file, err := export.Encode(req.Format)
+	if err != nil {
+		return &authpb.ExportAccountDataResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ExportAccountDataResponse{
+		Success:     true,
+		Data:        file.Data,
+		ContentType: file.ContentType,
+		Filename:    file.Filename,
+		Message:     "Account data exported successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RequestAccountDeletion(ctx context.Context, req *authpb.RequestAccountDeletionRequest) (*authpb.RequestAccountDeletionResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	scheduledAt, err := s.authService.RequestAccountDeletion(ctx, userID, req.CurrentPassword)
+	if err != nil {
+		return &authpb.RequestAccountDeletionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RequestAccountDeletionResponse{
+		Success:             true,
+		DeletionScheduledAt: scheduledAt.Unix(),
+		Message:             "Account scheduled for deletion",
+	}, nil
+}
+
+func (s *AuthGRPCServer) CancelAccountDeletion(ctx context.Context, req *authpb.CancelAccountDeletionRequest) (*authpb.CancelAccountDeletionResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.CancelAccountDeletion(ctx, userID); err != nil {
+		return &authpb.CancelAccountDeletionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.CancelAccountDeletionResponse{
+		Success: true,
+		Message: "Account deletion cancelled",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ListSessions(ctx context.Context, req *authpb.ListSessionsRequest) (*authpb.ListSessionsResponse, error) {
+	userID, err := sessionOwner(ctx, req.UserId, service.PermissionUsersRead)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the caller's own listing can contain its current session
+	currentSessionID := ""
+	if userID == ctx.Value("userID") {
+		currentSessionID, _ = ctx.Value("sessionID").(string)
+	}
+
+	sessions, err := s.authService.ListSessions(ctx, userID, currentSessionID)
+	if err != nil {
+		return &authpb.ListSessionsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	protoSessions := make([]*authpb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		protoSessions = append(protoSessions, convertToProtoSession(session))
+	}
+
+	return &authpb.ListSessionsResponse{
+		Success:  true,
+		Sessions: protoSessions,
+		Message:  "Sessions retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RevokeSession(ctx context.Context, req *authpb.RevokeSessionRequest) (*authpb.RevokeSessionResponse, error) {
+	userID, err := sessionOwner(ctx, req.UserId, service.PermissionUsersWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authService.RevokeSession(ctx, userID, req.SessionId); err != nil {
+		return &authpb.RevokeSessionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RevokeSessionResponse{
+		Success: true,
+		Message: "Session revoked successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RevokeAllSessions(ctx context.Context, req *authpb.RevokeAllSessionsRequest) (*authpb.RevokeAllSessionsResponse, error) {
+	userID, err := sessionOwner(ctx, req.UserId, service.PermissionUsersWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.authService.RevokeAllSessions(ctx, userID, req.ExceptSessionId)
+	if err != nil {
+		return &authpb.RevokeAllSessionsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RevokeAllSessionsResponse{
+		Success:      true,
+		RevokedCount: int32(count),
+		Message:      "Sessions revoked successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) AssignRole(ctx context.Context, req *authpb.AssignRoleRequest) (*authpb.AssignRoleResponse, error) {
+	roles, err := s.authService.AssignRole(ctx, req.UserId, req.Role)
+	if err != nil {
+		return &authpb.AssignRoleResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.AssignRoleResponse{
+		Success: true,
+		Roles:   roles,
+		Message: "Role assigned successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RemoveRole(ctx context.Context, req *authpb.RemoveRoleRequest) (*authpb.RemoveRoleResponse, error) {
+	roles, err := s.authService.RemoveRole(ctx, req.UserId, req.Role)
+	if err != nil {
+		return &authpb.RemoveRoleResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RemoveRoleResponse{
+		Success: true,
+		Roles:   roles,
+		Message: "Role removed successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) UnlockAccount(ctx context.Context, req *authpb.UnlockAccountRequest) (*authpb.UnlockAccountResponse, error) {
+	if err := s.authService.UnlockAccount(ctx, req.UserId); err != nil {
+		return &authpb.UnlockAccountResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.UnlockAccountResponse{
+		Success: true,
+		Message: "Account unlocked successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ListAuditEvents(ctx context.Context, req *authpb.ListAuditEventsRequest) (*authpb.ListAuditEventsResponse, error) {
+	query := service.AuditQuery{
+		EventType: req.EventType,
+		Outcome:   req.Outcome,
+		ActorID:   req.ActorId,
+		SubjectID: req.SubjectId,
+		Page:      int(req.GetPagination().GetPage()),
+		PageSize:  int(req.GetPagination().GetPageSize()),
+	}
+	if req.From > 0 {
+		query.From = time.Unix(req.From, 0)
+	}
+	if req.To > 0 {
+		query.To = time.Unix(req.To, 0)
+	}
+
+	events, pagination, err := s.authService.ListAuditEvents(ctx, query)
+	if err != nil {
+		return &authpb.ListAuditEventsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	protoEvents := make([]*authpb.AuditEvent, 0, len(events))
+	for _, event := range events {
+		protoEvents = append(protoEvents, convertToProtoAuditEvent(event))
+	}
+
+	return &authpb.ListAuditEventsResponse{
+		Success:    true,
+		Events:     protoEvents,
+		Pagination: convertToProtoPagination(pagination),
+		Message:    "Audit events retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ListUsers(ctx context.Context, req *authpb.ListUsersRequest) (*authpb.ListUsersResponse, error) {
+	query, err := userQueryFromListRequest(req.List)
+	if err != nil {
+		return &authpb.ListUsersResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	users, pagination, err := s.authService.ListUsers(ctx, query)
+	if err != nil {
+		return &authpb.ListUsersResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	protoUsers := make([]*authpb.User, 0, len(users))
+	for _, user := range users {
+		protoUsers = append(protoUsers, convertToProtoUser(user))
+	}
+
+	return &authpb.ListUsersResponse{
+		Success:    true,
+		Users:      protoUsers,
+		Pagination: convertToProtoPagination(pagination),
+		Message:    "Users retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) GetUser(ctx context.Context, req *authpb.GetUserRequest) (*authpb.GetUserResponse, error) {
+	user, err := s.authService.GetUser(ctx, req.UserId)
+	if err != nil {
+		return &authpb.GetUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.GetUserResponse{
+		Success: true,
+		User:    convertToProtoUser(user),
+		Message: "User retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) UpdateUser(ctx context.Context, req *authpb.UpdateUserRequest) (*authpb.UpdateUserResponse, error) {
+	user, err := s.authService.UpdateUser(ctx, req.UserId, service.UserUpdate{
+		Email:         req.Email,
+		FirstName:     req.FirstName,
+		LastName:      req.LastName,
+		EmailVerified: req.EmailVerified,
+	})
+	if err != nil {
+		return &authpb.UpdateUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.UpdateUserResponse{
+		Success: true,
+		User:    convertToProtoUser(user),
+		Message: "User updated successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) DeactivateUser(ctx context.Context, req *authpb.DeactivateUserRequest) (*authpb.DeactivateUserResponse, error) {
+	if err := s.authService.DeactivateUser(ctx, req.UserId); err != nil {
+		return &authpb.DeactivateUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.DeactivateUserResponse{
+		Success: true,
+		Message: "User deactivated successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ReactivateUser(ctx context.Context, req *authpb.ReactivateUserRequest) (*authpb.ReactivateUserResponse, error) {
+	if err := s.authService.ReactivateUser(ctx, req.UserId); err != nil {
+		return &authpb.ReactivateUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ReactivateUserResponse{
+		Success: true,
+		Message: "User reactivated successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ForceLogout(ctx context.Context, req *authpb.ForceLogoutRequest) (*authpb.ForceLogoutResponse, error) {
+	count, err := s.authService.ForceLogout(ctx, req.UserId)
+	if err != nil {
+		return &authpb.ForceLogoutResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ForceLogoutResponse{
+		Success:      true,
+		RevokedCount: int32(count),
+		Message:      "User logged out successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ResetMFA(ctx context.Context, req *authpb.ResetMFARequest) (*authpb.ResetMFAResponse, error) {
+	if err := s.authService.ResetMFA(ctx, req.UserId); err != nil {
+		return &authpb.ResetMFAResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ResetMFAResponse{
+		Success: true,
+		Message: "Two-factor authentication reset successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ImpersonateUser(ctx context.Context, req *authpb.ImpersonateUserRequest) (*authpb.ImpersonateUserResponse, error) {
+	actorID, _ := ctx.Value("userID").(string)
+	impersonation, err := s.authService.ImpersonateUser(ctx, actorID, req.UserId, req.Reason)
+	if err != nil {
+		return &authpb.ImpersonateUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.ImpersonateUserResponse{
+		Success:   true,
+		Token:     impersonation.AccessToken,
+		ExpiresAt: impersonation.ExpiresAt.Unix(),
+		User:      convertToProtoUser(impersonation.User),
+		Message:   "Impersonation started",
+	}, nil
+}
+
+func (s *AuthGRPCServer) StopImpersonation(ctx context.Context, req *authpb.StopImpersonationRequest) (*authpb.StopImpersonationResponse, error) {
+	err := s.authService.StopImpersonation(ctx, req.Token)
+	if err == service.ErrRevocationUnavailable {
+		return &authpb.StopImpersonationResponse{
+			Success: true,
+			Message: err.Error(),
+		}, nil
+	}
+	if err == service.ErrInvalidToken || err == service.ErrNotImpersonating {
+		return &authpb.StopImpersonationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Stopping impersonation failed", "error", err)
+		return &authpb.StopImpersonationResponse{
+			Success: false,
+			Message: "Failed to stop impersonation",
+		}, nil
+	}
+
+	return &authpb.StopImpersonationResponse{
+		Success: true,
+		Message: "Impersonation stopped",
+	}, nil
+}
+
+// userQueryFromListRequest translates a generic list request into a user query, rejecting
+// filters and operators that users cannot be listed by
+func userQueryFromListRequest(list *commonpb.ListRequest) (service.UserQuery, error) {
+	query := service.UserQuery{
+		Page:     int(list.GetPagination().GetPage()),
+		PageSize: int(list.GetPagination().GetPageSize()),
+	}
+
+	for _, filter := range list.GetFilters() {
+		if len(filter.Values) == 0 {
+			return query, fmt.Errorf("%w: filter %q has no values", service.ErrInvalidUserQuery, filter.Field)
+		}
+
+		switch {
+		case filter.Field == "search" && (filter.Operator == "like" || filter.Operator == "eq"):
+			query.Search = filter.Values[0]
+		case filter.Field == "email" && filter.Operator == "eq":
+			query.Email = filter.Values[0]
+		case filter.Field == "role" && (filter.Operator == "eq" || filter.Operator == "in"):
+			query.Roles = filter.Values
+		case filter.Field == "active" && filter.Operator == "eq":
+			active, err := strconv.ParseBool(filter.Values[0])
+			if err != nil {
+				return query, fmt.Errorf("%w: active must be true or false", service.ErrInvalidUserQuery)
+			}
+			query.Active = &active
+		default:
+			return query, fmt.Errorf("%w: unsupported filter %s %s", service.ErrInvalidUserQuery, filter.Field, filter.Operator)
+		}
+	}
+
+	for _, sort := range list.GetSorts() {
+		query.Sorts = append(query.Sorts, service.UserSort{Field: sort.Field, Descending: sort.Order == "desc"})
+	}
+	if len(query.Sorts) == 0 && list.GetPagination().GetSortBy() != "" {
+		query.Sorts = append(query.Sorts, service.UserSort{
+			Field:      list.GetPagination().GetSortBy(),
+			Descending: list.GetPagination().GetSortOrder() == "desc",
+		})
+	}
+
+	return query, nil
+}
+
+func (s *AuthGRPCServer) CreateServiceAccount(ctx context.Context, req *authpb.CreateServiceAccountRequest) (*authpb.CreateServiceAccountResponse, error) {
+	account, secret, err := s.authService.CreateServiceAccount(ctx, req.Name, req.Scopes)
+	if err != nil {
+		return &authpb.CreateServiceAccountResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.CreateServiceAccountResponse{
+		Success:        true,
+		ServiceAccount: convertToProtoServiceAccount(account),
+		ClientSecret:   secret,
+		Message:        "Service account created successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ListServiceAccounts(ctx context.Context, req *authpb.ListServiceAccountsRequest) (*authpb.ListServiceAccountsResponse, error) {
+	accounts, err := s.authService.ListServiceAccounts(ctx)
+	if err != nil {
+		return &authpb.ListServiceAccountsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	protoAccounts := make([]*authpb.ServiceAccount, 0, len(accounts))
+	for _, account := range accounts {
+		protoAccounts = append(protoAccounts, convertToProtoServiceAccount(account))
+	}
+
+	return &authpb.ListServiceAccountsResponse{
+		Success:         true,
+		ServiceAccounts: protoAccounts,
+		Message:         "Service accounts retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RotateServiceAccountSecret(ctx context.Context, req *authpb.RotateServiceAccountSecretRequest) (*authpb.RotateServiceAccountSecretResponse, error) {
+	secret, err := s.authService.RotateServiceAccountSecret(ctx, req.Id)
+	if err != nil {
+		return &authpb.RotateServiceAccountSecretResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RotateServiceAccountSecretResponse{
+		Success:      true,
+		ClientSecret: secret,
+		Message:      "Service account secret rotated successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) DeleteServiceAccount(ctx context.Context, req *authpb.DeleteServiceAccountRequest) (*authpb.DeleteServiceAccountResponse, error) {
+	if err := s.authService.DeleteServiceAccount(ctx, req.Id); err != nil {
+		return &authpb.DeleteServiceAccountResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.DeleteServiceAccountResponse{
+		Success: true,
+		Message: "Service account deleted successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) CreateAPIKey(ctx context.Context, req *authpb.CreateAPIKeyRequest) (*authpb.CreateAPIKeyResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != 0 {
+		t := time.Unix(req.ExpiresAt, 0)
+		expiresAt = &t
+	}
+
+	apiKey, key, err := s.authService.CreateAPIKey(ctx, userID, req.Name, req.Scopes, expiresAt)
+	if err != nil {
+		return &authpb.CreateAPIKeyResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.CreateAPIKeyResponse{
+		Success: true,
+		ApiKey:  convertToProtoAPIKey(apiKey),
+		Key:     key,
+		Message: "API key created successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ListAPIKeys(ctx context.Context, req *authpb.ListAPIKeysRequest) (*authpb.ListAPIKeysResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	keys, err := s.authService.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return &authpb.ListAPIKeysResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	protoKeys := make([]*authpb.APIKey, 0, len(keys))
+	for _, key := range keys {
+		protoKeys = append(protoKeys, convertToProtoAPIKey(key))
+	}
+
+	return &authpb.ListAPIKeysResponse{
+		Success: true,
+		ApiKeys: protoKeys,
+		Message: "API keys retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RevokeAPIKey(ctx context.Context, req *authpb.RevokeAPIKeyRequest) (*authpb.RevokeAPIKeyResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.RevokeAPIKey(ctx, userID, req.Id); err != nil {
+		return &authpb.RevokeAPIKeyResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RevokeAPIKeyResponse{
+		Success: true,
+		Message: "API key revoked successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) CreateOrganization(ctx context.Context, req *authpb.CreateOrganizationRequest) (*authpb.CreateOrganizationResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	org, err := s.authService.CreateOrganization(ctx, userID, req.Name, req.Slug)
+	if err != nil {
+		return &authpb.CreateOrganizationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.CreateOrganizationResponse{
+		Success:      true,
+		Organization: convertToProtoOrganization(org),
+		Message:      "Organization created successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ListOrganizations(ctx context.Context, req *authpb.ListOrganizationsRequest) (*authpb.ListOrganizationsResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	orgs, err := s.authService.ListOrganizations(ctx, userID)
+	if err != nil {
+		return &authpb.ListOrganizationsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	protoOrgs := make([]*authpb.Organization, 0, len(orgs))
+	for _, org := range orgs {
+		protoOrgs = append(protoOrgs, convertToProtoOrganization(org))
+	}
+
+	return &authpb.ListOrganizationsResponse{
+		Success:       true,
+		Organizations: protoOrgs,
+		Message:       "Organizations retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) DeleteOrganization(ctx context.Context, req *authpb.DeleteOrganizationRequest) (*authpb.DeleteOrganizationResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.DeleteOrganization(ctx, userID, req.OrganizationId); err != nil {
+		return &authpb.DeleteOrganizationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.DeleteOrganizationResponse{
+		Success: true,
+		Message: "Organization deleted successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ListOrganizationMembers(ctx context.Context, req *authpb.ListOrganizationMembersRequest) (*authpb.ListOrganizationMembersResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	members, err := s.authService.ListOrganizationMembers(ctx, userID, req.OrganizationId)
+	if err != nil {
+		return &authpb.ListOrganizationMembersResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	protoMembers := make([]*authpb.OrganizationMember, 0, len(members))
+	for _, member := range members {
+		protoMembers = append(protoMembers, &authpb.OrganizationMember{
+			UserId:    member.UserID,
+			Email:     member.Email,
+			FirstName: member.FirstName,
+			LastName:  member.LastName,
+			Role:      member.Role,
+			JoinedAt:  member.JoinedAt.Unix(),
+		})
+	}
+
+	return &authpb.ListOrganizationMembersResponse{
+		Success: true,
+		Members: protoMembers,
+		Message: "Organization members retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) UpdateOrganizationMember(ctx context.Context, req *authpb.UpdateOrganizationMemberRequest) (*authpb.UpdateOrganizationMemberResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.UpdateOrganizationMember(ctx, userID, req.OrganizationId, req.UserId, req.Role); err != nil {
+		return &authpb.UpdateOrganizationMemberResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.UpdateOrganizationMemberResponse{
+		Success: true,
+		Message: "Organization member updated successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RemoveOrganizationMember(ctx context.Context, req *authpb.RemoveOrganizationMemberRequest) (*authpb.RemoveOrganizationMemberResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.RemoveOrganizationMember(ctx, userID, req.OrganizationId, req.UserId); err != nil {
+		return &authpb.RemoveOrganizationMemberResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RemoveOrganizationMemberResponse{
+		Success: true,
+		Message: "Organization member removed successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) InviteOrganizationMember(ctx context.Context, req *authpb.InviteOrganizationMemberRequest) (*authpb.InviteOrganizationMemberResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	invitation, err := s.authService.InviteOrganizationMember(ctx, userID, req.OrganizationId, req.Email, req.Role)
+	if err != nil {
+		return &authpb.InviteOrganizationMemberResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.InviteOrganizationMemberResponse{
+		Success:    true,
+		Invitation: convertToProtoOrganizationInvitation(invitation),
+		Message:    "Invitation sent successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) ListOrganizationInvitations(ctx context.Context, req *authpb.ListOrganizationInvitationsRequest) (*authpb.ListOrganizationInvitationsResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	invitations, err := s.authService.ListOrganizationInvitations(ctx, userID, req.OrganizationId)
+	if err != nil {
+		return &authpb.ListOrganizationInvitationsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	protoInvitations := make([]*authpb.OrganizationInvitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		protoInvitations = append(protoInvitations, convertToProtoOrganizationInvitation(invitation))
+	}
+
+	return &authpb.ListOrganizationInvitationsResponse{
+		Success:     true,
+		Invitations: protoInvitations,
+		Message:     "Invitations retrieved successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) RevokeOrganizationInvitation(ctx context.Context, req *authpb.RevokeOrganizationInvitationRequest) (*authpb.RevokeOrganizationInvitationResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.RevokeOrganizationInvitation(ctx, userID, req.OrganizationId, req.InvitationId); err != nil {
+		return &authpb.RevokeOrganizationInvitationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.RevokeOrganizationInvitationResponse{
+		Success: true,
+		Message: "Invitation revoked successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) AcceptOrganizationInvitation(ctx context.Context, req *authpb.AcceptOrganizationInvitationRequest) (*authpb.AcceptOrganizationInvitationResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	org, err := s.authService.AcceptOrganizationInvitation(ctx, userID, req.Token)
+	if err != nil {
+		return &authpb.AcceptOrganizationInvitationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.AcceptOrganizationInvitationResponse{
+		Success:      true,
+		Organization: convertToProtoOrganization(org),
+		Message:      "Invitation accepted successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) DeclineOrganizationInvitation(ctx context.Context, req *authpb.DeclineOrganizationInvitationRequest) (*authpb.DeclineOrganizationInvitationResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+
+	if err := s.authService.DeclineOrganizationInvitation(ctx, userID, req.Token); err != nil {
+		return &authpb.DeclineOrganizationInvitationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.DeclineOrganizationInvitationResponse{
+		Success: true,
+		Message: "Invitation declined successfully",
+	}, nil
+}
+
+func (s *AuthGRPCServer) SwitchOrganization(ctx context.Context, req *authpb.SwitchOrganizationRequest) (*authpb.SwitchOrganizationResponse, error) {
+	userID, _ := ctx.Value("userID").(string)
+	sessionID, _ := ctx.Value("sessionID").(string)
+
+	token, refreshToken, err := s.authService.SwitchOrganization(ctx, userID, sessionID, req.OrganizationId)
+	if err != nil {
+		return &authpb.SwitchOrganizationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &authpb.SwitchOrganizationResponse{
+		Success:      true,
+		Token:        token,
+		RefreshToken: refreshToken,
+		Message:      "Organization switched successfully",
+	}, nil
+}
+
+// sessionOwner resolves whose sessions a call may act on: the authenticated caller by default,
+// or another user if the caller holds permission
+func sessionOwner(ctx context.Context, requestedUserID, permission string) (string, error) {
+	callerID, _ := ctx.Value("userID").(string)
+	if requestedUserID == "" || requestedUserID == callerID {
+		return callerID, nil
+	}
+
+	if !authMiddleware.HasPermissions(ctx, permission) {
+		return "", status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+
+	return requestedUserID, nil
+}
+
+func convertToProtoUser(user *service.User) *authpb.User {
+	if user == nil {
+		return nil
+	}
+
+	return &authpb.User{
+		Id:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Roles:         user.Roles,
+		CreatedAt:     user.CreatedAt.Unix(),
+		UpdatedAt:     user.UpdatedAt.Unix(),
+		Active:        user.Active,
+		EmailVerified: user.EmailVerified,
+	}
+}
+
+func convertToProtoSession(session *service.Session) *authpb.Session {
+	return &authpb.Session{
+		Id:         session.ID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IpAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt.Unix(),
+		LastSeenAt: session.LastSeenAt.Unix(),
+		ExpiresAt:  session.ExpiresAt.Unix(),
+		Current:    session.Current,
+	}
+}
+
+func convertToProtoAuditEvent(event *service.AuditEvent) *authpb.AuditEvent {
+	return &authpb.AuditEvent{
+		Id:        event.ID,
+		EventType: event.EventType,
+		Outcome:   event.Outcome,
+		ActorId:   event.ActorID,
+		SubjectId: event.SubjectID,
+		IpAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		RequestId: event.RequestID,
+		Details:   event.Details,
+		CreatedAt: event.CreatedAt.Unix(),
+	}
+}
+
+func convertToProtoPagination(pagination *service.Pagination) *commonpb.PaginationResponse {
+	return &commonpb.PaginationResponse{
+		Page:       int32(pagination.Page),
+		PageSize:   int32(pagination.PageSize),
+		TotalItems: int32(pagination.TotalItems),
+		TotalPages: int32(pagination.TotalPages),
+		HasNext:    pagination.HasNext,
+		HasPrev:    pagination.HasPrev,
+	}
+}
+
+func convertToProtoServiceAccount(account *service.ServiceAccount) *authpb.ServiceAccount {
+	return &authpb.ServiceAccount{
+		Id:        account.ID,
+		ClientId:  account.ClientID,
+		Name:      account.Name,
+		Scopes:    account.Scopes,
+		CreatedAt: account.CreatedAt.Unix(),
+		UpdatedAt: account.UpdatedAt.Unix(),
+	}
+}
+
+func convertToProtoAPIKey(key *service.APIKey) *authpb.APIKey {
+	protoKey := &authpb.APIKey{
+		Id:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedAt:  key.CreatedAt.Unix(),
+		LastUsedIp: key.LastUsedIP,
+	}
+	if key.ExpiresAt != nil {
+		protoKey.ExpiresAt = key.ExpiresAt.Unix()
+	}
+	if key.LastUsedAt != nil {
+		protoKey.LastUsedAt = key.LastUsedAt.Unix()
+	}
+	return protoKey
+}
+
+func convertToProtoOrganization(org *service.Organization) *authpb.Organization {
+	return &authpb.Organization{
+		Id:        org.ID,
+		Name:      org.Name,
+		Slug:      org.Slug,
+		Role:      org.Role,
+		CreatedAt: org.CreatedAt.Unix(),
+		UpdatedAt: org.UpdatedAt.Unix(),
+	}
+}
+
+func convertToProtoOrganizationInvitation(invitation *service.OrganizationInvitation) *authpb.OrganizationInvitation {
+	return &authpb.OrganizationInvitation{
+		Id:             invitation.ID,
+		OrganizationId: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		InvitedBy:      invitation.InvitedBy,
+		CreatedAt:      invitation.CreatedAt.Unix(),
+		ExpiresAt:      invitation.ExpiresAt.Unix(),
 	}
 }
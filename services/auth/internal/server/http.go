@@ -4,14 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	commonErrors "github.com/VariableSan/go-factory-microservice/pkg/common/errors"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/logger"
+	"github.com/VariableSan/go-factory-microservice/pkg/common/ratelimit"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/response"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/tracing"
+	"github.com/VariableSan/go-factory-microservice/pkg/common/validation"
+	commonpb "github.com/VariableSan/go-factory-microservice/pkg/proto/common"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/keys"
 	authMiddleware "github.com/VariableSan/go-factory-microservice/services/auth/internal/middleware"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/password"
 	"github.com/VariableSan/go-factory-microservice/services/auth/internal/service"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/webauthn"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
@@ -21,18 +31,18 @@ type HTTPServer struct {
 	server         *http.Server
 	authService    *service.AuthService
 	logger         *logger.Logger
-	jwtSecret      string
+	keys           *keys.KeySet
 	tracingManager *tracing.TracingManager
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
 }
 
 type RegisterRequest struct {
 	Email     string `json:"email" validate:"required,email"`
-	Password  string `json:"password" validate:"required,min=6"`
+	Password  string `json:"password" validate:"required"`
 	FirstName string `json:"first_name" validate:"required"`
 	LastName  string `json:"last_name" validate:"required"`
 }
@@ -41,23 +51,141 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type FinishPasskeyRegistrationRequest struct {
+	CeremonyID string                       `json:"ceremony_id" validate:"required"`
+	Name       string                       `json:"name"`
+	Credential webauthn.AttestationResponse `json:"credential" validate:"required"`
+}
+
+type FinishPasskeyLoginRequest struct {
+	CeremonyID string                     `json:"ceremony_id" validate:"required"`
+	Credential webauthn.AssertionResponse `json:"credential" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// UpdateProfileRequest changes the caller's name; omitted fields are left alone
+type UpdateProfileRequest struct {
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	// UpdatedAt is the profile's updated_at as last read; the update fails if it changed since
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+type ChangeEmailRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewEmail        string `json:"new_email" validate:"required,email"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type DeleteAccountRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type CreateServiceAccountRequest struct {
+	Name   string   `json:"name" validate:"required"`
+	Scopes []string `json:"scopes"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"` // Omit for a key that never expires
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required"`
+	Slug string `json:"slug" validate:"required"`
+}
+
+type OrganizationRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type InviteOrganizationMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required"`
+}
+
+type OrganizationInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// SwitchOrganizationRequest selects the active organization; an empty ID leaves none active
+type SwitchOrganizationRequest struct {
+	OrganizationID string `json:"organization_id"`
+}
+
+// ImpersonateUserRequest gives the reason an administrator impersonates a user, for the audit log
+type ImpersonateUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// UpdateUserRequest edits a user; omitted fields are left alone
+type UpdateUserRequest struct {
+	Email         *string `json:"email" validate:"omitempty,email"`
+	FirstName     *string `json:"first_name"`
+	LastName      *string `json:"last_name"`
+	EmailVerified *bool   `json:"email_verified"`
+}
+
 type UserResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Roles     []string  `json:"roles"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Active    bool      `json:"active"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Roles         []string  `json:"roles"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Active        bool      `json:"active"`
+	EmailVerified bool      `json:"email_verified"`
 }
 
-func NewHTTPServer(authService *service.AuthService, port, jwtSecret string, logger *logger.Logger, tracingManager *tracing.TracingManager) *HTTPServer {	
+func NewHTTPServer(authService *service.AuthService, port string, keySet *keys.KeySet, trustedProxies authMiddleware.TrustedProxies, limiter ratelimit.Limiter, logger *logger.Logger, tracingManager *tracing.TracingManager) *HTTPServer {	
 	r := chi.NewRouter()
 	
 	// Middleware
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	r.Use(authMiddleware.ClientInfo(trustedProxies))
+	if limiter != nil {
+		r.Use(ratelimit.Middleware(limiter, authMiddleware.RateLimitKeyByClientIP))
+	}
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(60 * time.Second))
@@ -70,9 +198,9 @@ func NewHTTPServer(authService *service.AuthService, port, jwtSecret string, log
 	// CORS
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"}, // Configure properly for production
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", authMiddleware.DeviceNameHeader, authMiddleware.HeaderAPIKey},
+		ExposedHeaders:   []string{"Link", "Content-Disposition", ratelimit.HeaderLimit, ratelimit.HeaderRemaining, ratelimit.HeaderReset, ratelimit.HeaderRetryAfter},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -80,7 +208,7 @@ func NewHTTPServer(authService *service.AuthService, port, jwtSecret string, log
 	httpServer := &HTTPServer{
 		authService:    authService,
 		logger:         logger.WithComponent("http-server"),
-		jwtSecret:      jwtSecret,
+		keys:           keySet,
 		tracingManager: tracingManager,
 		server: &http.Server{
 			Addr:         ":" + port,
@@ -102,50 +230,206 @@ func (s *HTTPServer) setupRoutes(r chi.Router) {
 		r.Post("/login", s.login)
 		r.Post("/register", s.register)
 		r.Post("/refresh", s.refreshToken)
+		r.Post("/password/forgot", s.forgotPassword)
+		r.Post("/password/reset", s.resetPassword)
+		r.Post("/email/verify", s.verifyEmail)
+		r.Post("/email/resend", s.resendVerification)
+		r.Post("/email/change/confirm", s.confirmEmailChange)
+		r.Post("/mfa/verify", s.verifyMFA)
+		r.Post("/passkeys/login/begin", s.beginPasskeyLogin)
+		r.Post("/passkeys/login/finish", s.finishPasskeyLogin)
 		
 		// Protected routes
 		r.Group(func(r chi.Router) {
-			r.Use(authMiddleware.AuthMiddleware(s.jwtSecret))
+			r.Use(authMiddleware.AuthMiddleware(s.keys.Keyfunc, s.authService))
+			r.Post("/logout", s.logout)
+			r.Post("/impersonation/stop", s.stopImpersonation)
 			r.Get("/profile", s.getProfile)
+			r.Patch("/profile", s.updateProfile)
+			r.Post("/profile/password", s.changePassword)
+			r.Post("/profile/email", s.requestEmailChange)
+			r.Get("/profile/export", s.exportAccountData)
+			r.Post("/profile/deletion", s.requestAccountDeletion)
+			r.Delete("/profile/deletion", s.cancelAccountDeletion)
 			r.Get("/validate", s.validateToken)
+			r.Get("/sessions", s.listSessions)
+			r.Delete("/sessions", s.revokeAllSessions)
+			r.Delete("/sessions/{id}", s.revokeSession)
+			r.Post("/mfa/totp", s.enrollTOTP)
+			r.Post("/mfa/totp/confirm", s.confirmTOTP)
+			r.Post("/mfa/totp/disable", s.disableTOTP)
+			r.Get("/passkeys", s.listPasskeys)
+			r.Post("/passkeys/register/begin", s.beginPasskeyRegistration)
+			r.Post("/passkeys/register/finish", s.finishPasskeyRegistration)
+			r.Delete("/passkeys/{id}", s.deletePasskey)
+			r.Get("/api-keys", s.listAPIKeys)
+			r.Post("/api-keys", s.createAPIKey)
+			r.Delete("/api-keys/{id}", s.revokeAPIKey)
+			r.Get("/organizations", s.listOrganizations)
+			r.Post("/organizations", s.createOrganization)
+			r.Post("/organizations/switch", s.switchOrganization)
+			r.Post("/organizations/invitations/accept", s.acceptOrganizationInvitation)
+			r.Post("/organizations/invitations/decline", s.declineOrganizationInvitation)
+			r.Delete("/organizations/{id}", s.deleteOrganization)
+			r.Get("/organizations/{id}/members", s.listOrganizationMembers)
+			r.Patch("/organizations/{id}/members/{userID}", s.updateOrganizationMember)
+			r.Delete("/organizations/{id}/members/{userID}", s.removeOrganizationMember)
+			r.Get("/organizations/{id}/invitations", s.listOrganizationInvitations)
+			r.Post("/organizations/{id}/invitations", s.inviteOrganizationMember)
+			r.Delete("/organizations/{id}/invitations/{invitationID}", s.revokeOrganizationInvitation)
+		})
+
+		// Role management
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.AuthMiddleware(s.keys.Keyfunc, s.authService))
+			r.Use(authMiddleware.RequirePermission(service.PermissionRolesManage))
+			r.Get("/users/{id}/roles", s.getUserRoles)
+			r.Post("/users/{id}/roles", s.assignRole)
+			r.Delete("/users/{id}/roles/{role}", s.removeRole)
+		})
+
+		// Account administration
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.AuthMiddleware(s.keys.Keyfunc, s.authService))
+			r.Use(authMiddleware.RequirePermission(service.PermissionUsersWrite))
+			r.Post("/users/{id}/unlock", s.unlockAccount)
+		})
+
+		// Audit log
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.AuthMiddleware(s.keys.Keyfunc, s.authService))
+			r.Use(authMiddleware.RequirePermission(service.PermissionAuditRead))
+			r.Get("/audit", s.listAuditEvents)
 		})
+
+		// Service account management
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.AuthMiddleware(s.keys.Keyfunc, s.authService))
+			r.Use(authMiddleware.RequirePermission(service.PermissionServiceAccountsManage))
+			r.Get("/service-accounts", s.listServiceAccounts)
+			r.Post("/service-accounts", s.createServiceAccount)
+			r.Post("/service-accounts/{id}/secret", s.rotateServiceAccountSecret)
+			r.Delete("/service-accounts/{id}", s.deleteServiceAccount)
+		})
+	})
+
+	// User administration
+	r.Route("/api/v1/admin/users", func(r chi.Router) {
+		r.Use(authMiddleware.AuthMiddleware(s.keys.Keyfunc, s.authService))
+		r.Use(authMiddleware.RequireRole(service.RoleAdmin))
+		r.Get("/", s.listUsers)
+		r.Get("/{id}", s.getUser)
+		r.Patch("/{id}", s.updateUser)
+		r.Post("/{id}/deactivate", s.deactivateUser)
+		r.Post("/{id}/reactivate", s.reactivateUser)
+		r.Post("/{id}/logout", s.forceLogout)
+		r.Post("/{id}/mfa/reset", s.resetMFA)
+		r.Post("/{id}/impersonate", s.impersonateUser)
 	})
 
+	// Public signing keys for verifying access tokens
+	r.Get("/.well-known/jwks.json", s.jwks)
+
+	// OAuth2 token endpoint; service accounts use it for the client credentials grant
+	r.Post("/oauth2/token", s.token)
+	r.Post("/oauth2/introspect", s.introspect)
+
+	// OpenID Connect provider endpoints, only served when an issuer is configured
+	if s.authService.OIDCEnabled() {
+		s.setupOIDCRoutes(r)
+	}
+
 	// Health check
 	r.Get("/health", s.health)
 }
 
 func (s *HTTPServer) login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "Invalid request body")
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
 		return
 	}
 
-	user, token, refreshToken, err := s.authService.Login(r.Context(), req.Email, req.Password)
+	result, err := s.authService.Login(r.Context(), req.Email, req.Password)
+	if err == service.ErrEmailNotVerified {
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrEmailNotVerified, err.Error()))
+		return
+	}
+	if err == service.ErrAccountLocked {
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrAccountLocked, err.Error()))
+		return
+	}
+	if err == service.ErrLoginThrottled {
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrTooManyRequests, err.Error()))
+		return
+	}
 	if err != nil {
-		s.logger.Error("Login failed", "error", err, "email", req.Email)
+		s.logger.Error("Login failed", "error", err)
 		response.Unauthorized(w, err.Error())
 		return
 	}
 
+	if result.MFARequired() {
+		response.SuccessWithMessage(w, map[string]interface{}{
+			"mfa_required": true,
+			"mfa_token":    result.MFAToken,
+		}, "Two-factor authentication required")
+		return
+	}
+
 	response.SuccessWithMessage(w, map[string]interface{}{
-		"token":         token,
-		"refresh_token": refreshToken,
-		"user":          convertToUserResponse(user),
+		"token":         result.AccessToken,
+		"refresh_token": result.RefreshToken,
+		"user":          convertToUserResponse(result.User),
+	}, "Login successful")
+}
+
+func (s *HTTPServer) verifyMFA(w http.ResponseWriter, r *http.Request) {
+	var req VerifyMFARequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	result, err := s.authService.VerifyMFA(r.Context(), req.MFAToken, req.Code)
+	if err == service.ErrTooManyMFAAttempts {
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrTooManyRequests, err.Error()))
+		return
+	}
+	if err == service.ErrAccountLocked {
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrAccountLocked, err.Error()))
+		return
+	}
+	if err == service.ErrMFAUnavailable {
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrServiceUnavailable, err.Error()))
+		return
+	}
+	if err != nil {
+		s.logger.Error("MFA verification failed", "error", err)
+		response.Unauthorized(w, err.Error())
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"token":         result.AccessToken,
+		"refresh_token": result.RefreshToken,
+		"user":          convertToUserResponse(result.User),
 	}, "Login successful")
 }
 
 func (s *HTTPServer) register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "Invalid request body")
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
 		return
 	}
 
 	user, err := s.authService.Register(r.Context(), req.Email, req.Password, req.FirstName, req.LastName)
+	if writePasswordPolicyError(w, "password", err) {
+		return
+	}
 	if err != nil {
-		s.logger.Error("Registration failed", "error", err, "email", req.Email)
+		s.logger.Error("Registration failed", "error", err)
 		response.BadRequest(w, err.Error())
 		return
 	}
@@ -157,12 +441,12 @@ func (s *HTTPServer) register(w http.ResponseWriter, r *http.Request) {
 
 func (s *HTTPServer) refreshToken(w http.ResponseWriter, r *http.Request) {
 	var req RefreshTokenRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "Invalid request body")
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
 		return
 	}
 
-	newToken, err := s.authService.RefreshToken(r.Context(), req.RefreshToken)
+	newToken, newRefreshToken, err := s.authService.RefreshToken(r.Context(), req.RefreshToken)
 	if err != nil {
 		s.logger.Error("Token refresh failed", "error", err)
 		response.Unauthorized(w, err.Error())
@@ -171,10 +455,89 @@ func (s *HTTPServer) refreshToken(w http.ResponseWriter, r *http.Request) {
 
 	response.SuccessWithMessage(w, map[string]interface{}{
 		"token":         newToken,
-		"refresh_token": req.RefreshToken, // Keep the same refresh token
+		"refresh_token": newRefreshToken,
 	}, "Token refreshed successfully")
 }
 
+func (s *HTTPServer) forgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req ForgotPasswordRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	if err := s.authService.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		s.logger.Error("Password reset request failed", "error", err)
+		response.Error(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "If the email is registered, a password reset link has been sent")
+}
+
+func (s *HTTPServer) resetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	if err := s.authService.ResetPassword(r.Context(), req.Token, req.NewPassword); err != nil {
+		if writePasswordPolicyError(w, "new_password", err) {
+			return
+		}
+		if err == service.ErrInvalidResetToken {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		s.logger.Error("Password reset failed", "error", err)
+		response.Error(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Password reset successfully")
+}
+
+func (s *HTTPServer) verifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req VerifyEmailRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	if err := s.authService.VerifyEmail(r.Context(), req.Token); err != nil {
+		if err == service.ErrInvalidVerificationToken {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		s.logger.Error("Email verification failed", "error", err)
+		response.Error(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Email verified successfully")
+}
+
+func (s *HTTPServer) resendVerification(w http.ResponseWriter, r *http.Request) {
+	var req ResendVerificationRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	if err := s.authService.ResendVerification(r.Context(), req.Email); err != nil {
+		if err == service.ErrVerificationThrottled {
+			response.Error(w, commonErrors.NewAppError(commonErrors.ErrTooManyRequests, err.Error()))
+			return
+		}
+		s.logger.Error("Resend verification failed", "error", err)
+		response.Error(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "If the email is registered and unverified, a verification link has been sent")
+}
+
 func (s *HTTPServer) getProfile(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("userID").(string)
 	
@@ -190,60 +553,1135 @@ func (s *HTTPServer) getProfile(w http.ResponseWriter, r *http.Request) {
 	}, "User profile retrieved successfully")
 }
 
+func (s *HTTPServer) updateProfile(w http.ResponseWriter, r *http.Request) {
+	var req UpdateProfileRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	update := service.ProfileUpdate{FirstName: req.FirstName, LastName: req.LastName}
+	if req.UpdatedAt != nil {
+		update.UpdatedAt = *req.UpdatedAt
+	}
+
+	user, err := s.authService.UpdateProfile(r.Context(), r.Context().Value("userID").(string), update)
+	if err != nil {
+		s.writeProfileError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"user": convertToUserResponse(user),
+	}, "Profile updated successfully")
+}
+
+func (s *HTTPServer) changePassword(w http.ResponseWriter, r *http.Request) {
+	var req ChangePasswordRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	sessionID, _ := r.Context().Value("sessionID").(string)
+
+	if err := s.authService.ChangePassword(r.Context(), userID, sessionID, req.CurrentPassword, req.NewPassword); err != nil {
+		if writePasswordPolicyError(w, "new_password", err) {
+			return
+		}
+		s.writeProfileError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Password changed successfully")
+}
+
+func (s *HTTPServer) requestEmailChange(w http.ResponseWriter, r *http.Request) {
+	var req ChangeEmailRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	if err := s.authService.RequestEmailChange(r.Context(), userID, req.CurrentPassword, req.NewEmail); err != nil {
+		s.writeProfileError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "A confirmation link has been sent to the new email address")
+}
+
+func (s *HTTPServer) confirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	var req ConfirmEmailChangeRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	if err := s.authService.ConfirmEmailChange(r.Context(), req.Token); err != nil {
+		s.writeProfileError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Email address changed successfully")
+}
+
+func (s *HTTPServer) exportAccountData(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != service.ExportFormatZip && format != service.ExportFormatJSON {
+		response.BadRequest(w, service.ErrInvalidExportFormat.Error())
+		return
+	}
+
+	export, err := s.authService.ExportAccountData(r.Context(), r.Context().Value("userID").(string))
+	if err != nil {
+		s.writeProfileError(w, err)
+		return
+	}
+
+	file, err := export.Encode(format)
+	if err != nil {
+		s.logger.Error("Failed to encode account export", "error", err, "user_id", export.UserID)
+		response.InternalError(w, "Failed to export account data")
+		return
+	}
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.Filename))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Data)
+}
+
+func (s *HTTPServer) requestAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	var req DeleteAccountRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	scheduledAt, err := s.authService.RequestAccountDeletion(r.Context(), r.Context().Value("userID").(string), req.CurrentPassword)
+	if err != nil {
+		s.writeProfileError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"deletion_scheduled_at": scheduledAt,
+	}, "Account scheduled for deletion")
+}
+
+func (s *HTTPServer) cancelAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	if err := s.authService.CancelAccountDeletion(r.Context(), r.Context().Value("userID").(string)); err != nil {
+		s.writeProfileError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Account deletion cancelled")
+}
+
+func (s *HTTPServer) writeProfileError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrUserNotFound:
+		response.NotFound(w, err.Error())
+	case service.ErrIncorrectPassword:
+		response.Unauthorized(w, err.Error())
+	case service.ErrUserModified:
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrConflict, err.Error()))
+	case service.ErrEmailTaken:
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrAlreadyExists, err.Error()))
+	case service.ErrEmailUnchanged, service.ErrInvalidEmailChangeToken:
+		response.BadRequest(w, err.Error())
+	case service.ErrDeletionNotScheduled:
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrConflict, err.Error()))
+	case service.ErrNotAllowedOnImpersonation:
+		response.Forbidden(w, err.Error())
+	default:
+		s.logger.Error("Profile update failed", "error", err)
+		response.Error(w, err)
+	}
+}
+
+func (s *HTTPServer) logout(w http.ResponseWriter, r *http.Request) {
+	err := s.authService.Logout(r.Context(), bearerToken(r))
+	if err == service.ErrRevocationUnavailable {
+		response.SuccessWithMessage(w, nil, err.Error())
+		return
+	}
+	if err == service.ErrInvalidToken {
+		response.Unauthorized(w, err.Error())
+		return
+	}
+	if err != nil {
+		s.logger.Error("Logout failed", "error", err)
+		response.InternalError(w, "Failed to log out")
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Logout successful")
+}
+
+func (s *HTTPServer) stopImpersonation(w http.ResponseWriter, r *http.Request) {
+	err := s.authService.StopImpersonation(r.Context(), bearerToken(r))
+	if err == service.ErrRevocationUnavailable {
+		response.SuccessWithMessage(w, nil, err.Error())
+		return
+	}
+	if err == service.ErrNotImpersonating {
+		response.BadRequest(w, err.Error())
+		return
+	}
+	if err == service.ErrInvalidToken {
+		response.Unauthorized(w, err.Error())
+		return
+	}
+	if err != nil {
+		s.logger.Error("Stopping impersonation failed", "error", err)
+		response.InternalError(w, "Failed to stop impersonation")
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Impersonation stopped")
+}
+
 func (s *HTTPServer) validateToken(w http.ResponseWriter, r *http.Request) {
-	token := r.Header.Get("Authorization")
+	token := requestCredential(r)
 	if token == "" {
 		response.Error(w, errors.New("authorization header required"))
 		return
 	}
 
-	// Remove "Bearer " prefix if present
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
 	user, err := s.authService.ValidateToken(r.Context(), token)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
 
-	response.SuccessWithMessage(w, map[string]interface{}{
+	data := map[string]interface{}{
 		"user":  convertToUserResponse(user),
-		"roles": []string{}, // No roles in simplified model
-	}, "Token is valid")
+		"roles": user.Roles,
+	}
+	if user.OrganizationID != "" {
+		data["organization_id"] = user.OrganizationID
+		data["organization_role"] = user.OrganizationRole
+	}
+	if user.ActorID != "" {
+		data["actor_id"] = user.ActorID
+	}
+
+	response.SuccessWithMessage(w, data, "Token is valid")
 }
 
-func (s *HTTPServer) health(w http.ResponseWriter, r *http.Request) {
+func (s *HTTPServer) listSessions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	sessionID, _ := r.Context().Value("sessionID").(string)
+
+	sessions, err := s.authService.ListSessions(r.Context(), userID, sessionID)
+	if err != nil {
+		s.logger.Error("List sessions failed", "error", err, "userID", userID)
+		response.Error(w, err)
+		return
+	}
+
 	response.SuccessWithMessage(w, map[string]interface{}{
-		"service":   "auth",
-		"timestamp": time.Now().Unix(),
-	}, "Service is healthy")
+		"sessions": sessions,
+	}, "Sessions retrieved successfully")
 }
 
-func (s *HTTPServer) Start() error {
-	s.logger.Info("Starting HTTP server", "addr", s.server.Addr)
-	return s.server.ListenAndServe()
-}
+func (s *HTTPServer) revokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+	sessionID := chi.URLParam(r, "id")
+
+	if err := s.authService.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		if err == service.ErrSessionNotFound {
+			response.NotFound(w, err.Error())
+			return
+		}
+		s.logger.Error("Revoke session failed", "error", err, "userID", userID)
+		response.Error(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Session revoked successfully")
+}
+
+// revokeAllSessions revokes every session of the caller; pass ?except_current=true to stay logged in
+func (s *HTTPServer) revokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	exceptSessionID := ""
+	if r.URL.Query().Get("except_current") == "true" {
+		exceptSessionID, _ = r.Context().Value("sessionID").(string)
+	}
+
+	count, err := s.authService.RevokeAllSessions(r.Context(), userID, exceptSessionID)
+	if err != nil {
+		s.logger.Error("Revoke sessions failed", "error", err, "userID", userID)
+		response.Error(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"revoked_count": count,
+	}, "Sessions revoked successfully")
+}
+
+func (s *HTTPServer) enrollTOTP(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	enrollment, err := s.authService.EnrollTOTP(r.Context(), userID)
+	if err != nil {
+		s.writeMFAError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, enrollment, "Scan the URI with an authenticator app and confirm with a code")
+}
+
+func (s *HTTPServer) confirmTOTP(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req MFACodeRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	recoveryCodes, err := s.authService.ConfirmTOTP(r.Context(), userID, req.Code)
+	if err != nil {
+		s.writeMFAError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"recovery_codes": recoveryCodes,
+	}, "Two-factor authentication enabled; store the recovery codes securely")
+}
+
+func (s *HTTPServer) disableTOTP(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req MFACodeRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	if err := s.authService.DisableTOTP(r.Context(), userID, req.Code); err != nil {
+		s.writeMFAError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Two-factor authentication disabled")
+}
+
+// writeMFAError maps two-factor authentication errors to HTTP responses
+func (s *HTTPServer) writeMFAError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrMFAAlreadyEnabled, service.ErrMFANotEnabled, service.ErrInvalidMFACode:
+		response.BadRequest(w, err.Error())
+	case service.ErrUserNotFound:
+		response.NotFound(w, err.Error())
+	case service.ErrNotAllowedOnImpersonation:
+		response.Forbidden(w, err.Error())
+	default:
+		s.logger.Error("Two-factor authentication request failed", "error", err)
+		response.Error(w, err)
+	}
+}
+
+func (s *HTTPServer) beginPasskeyRegistration(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	registration, err := s.authService.BeginPasskeyRegistration(r.Context(), userID)
+	if err != nil {
+		s.writePasskeyError(w, err)
+		return
+	}
+
+	response.Success(w, registration)
+}
+
+func (s *HTTPServer) finishPasskeyRegistration(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var req FinishPasskeyRegistrationRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	passkey, err := s.authService.FinishPasskeyRegistration(r.Context(), userID, req.CeremonyID, req.Name, &req.Credential)
+	if err != nil {
+		s.writePasskeyError(w, err)
+		return
+	}
+
+	response.Created(w, passkey)
+}
+
+func (s *HTTPServer) beginPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	login, err := s.authService.BeginPasskeyLogin(r.Context())
+	if err != nil {
+		s.writePasskeyError(w, err)
+		return
+	}
+
+	response.Success(w, login)
+}
+
+func (s *HTTPServer) finishPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	var req FinishPasskeyLoginRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	result, err := s.authService.FinishPasskeyLogin(r.Context(), req.CeremonyID, &req.Credential)
+	switch err {
+	case nil:
+	case service.ErrEmailNotVerified:
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrEmailNotVerified, err.Error()))
+		return
+	case service.ErrInvalidPasskey, service.ErrInvalidCeremony:
+		s.logger.Error("Passkey login failed", "error", err)
+		response.Unauthorized(w, err.Error())
+		return
+	default:
+		s.writePasskeyError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"token":         result.AccessToken,
+		"refresh_token": result.RefreshToken,
+		"user":          convertToUserResponse(result.User),
+	}, "Login successful")
+}
+
+func (s *HTTPServer) listPasskeys(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	passkeys, err := s.authService.ListPasskeys(r.Context(), userID)
+	if err != nil {
+		s.writePasskeyError(w, err)
+		return
+	}
+
+	response.Success(w, passkeys)
+}
+
+func (s *HTTPServer) deletePasskey(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := s.authService.DeletePasskey(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		s.writePasskeyError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Passkey removed successfully")
+}
+
+// writePasskeyError maps passkey errors to HTTP responses
+func (s *HTTPServer) writePasskeyError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrInvalidPasskey, service.ErrInvalidCeremony:
+		response.BadRequest(w, err.Error())
+	case service.ErrPasskeyExists:
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrAlreadyExists, err.Error()))
+	case service.ErrPasskeyNotFound, service.ErrUserNotFound:
+		response.NotFound(w, err.Error())
+	case service.ErrPasskeysUnavailable:
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrServiceUnavailable, err.Error()))
+	case service.ErrNotAllowedOnImpersonation:
+		response.Forbidden(w, err.Error())
+	default:
+		s.logger.Error("Passkey request failed", "error", err)
+		response.Error(w, err)
+	}
+}
+
+func (s *HTTPServer) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := s.authService.ListAPIKeys(r.Context(), r.Context().Value("userID").(string))
+	if err != nil {
+		s.writeAPIKeyError(w, err)
+		return
+	}
+
+	response.Success(w, keys)
+}
+
+func (s *HTTPServer) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateAPIKeyRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	apiKey, key, err := s.authService.CreateAPIKey(r.Context(), userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		s.writeAPIKeyError(w, err)
+		return
+	}
+
+	response.Created(w, map[string]interface{}{
+		"api_key": apiKey,
+		"key":     key,
+	})
+}
+
+func (s *HTTPServer) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := s.authService.RevokeAPIKey(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		s.writeAPIKeyError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "API key revoked successfully")
+}
+
+// writeAPIKeyError maps API key errors to HTTP responses
+func (s *HTTPServer) writeAPIKeyError(w http.ResponseWriter, err error) {
+	switch {
+	case err == service.ErrAPIKeyNotFound, err == service.ErrUserNotFound:
+		response.NotFound(w, err.Error())
+	case err == service.ErrAPIKeyNotAllowed, err == service.ErrNotAllowedOnImpersonation:
+		response.Forbidden(w, err.Error())
+	case err == service.ErrInvalidAPIKeyExpiry, errors.Is(err, service.ErrInvalidScope):
+		response.BadRequest(w, err.Error())
+	default:
+		s.logger.Error("API key management failed", "error", err)
+		response.Error(w, err)
+	}
+}
+
+func (s *HTTPServer) listOrganizations(w http.ResponseWriter, r *http.Request) {
+	orgs, err := s.authService.ListOrganizations(r.Context(), r.Context().Value("userID").(string))
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.Success(w, orgs)
+}
+
+func (s *HTTPServer) createOrganization(w http.ResponseWriter, r *http.Request) {
+	var req CreateOrganizationRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	org, err := s.authService.CreateOrganization(r.Context(), userID, req.Name, req.Slug)
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.Created(w, org)
+}
+
+func (s *HTTPServer) deleteOrganization(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := s.authService.DeleteOrganization(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Organization deleted successfully")
+}
+
+func (s *HTTPServer) listOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	members, err := s.authService.ListOrganizationMembers(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.Success(w, members)
+}
+
+func (s *HTTPServer) updateOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	var req OrganizationRoleRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	err := s.authService.UpdateOrganizationMember(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "userID"), req.Role)
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Organization member updated successfully")
+}
+
+// removeOrganizationMember removes a member; members remove themselves to leave the organization
+func (s *HTTPServer) removeOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	err := s.authService.RemoveOrganizationMember(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "userID"))
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Organization member removed successfully")
+}
+
+func (s *HTTPServer) listOrganizationInvitations(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	invitations, err := s.authService.ListOrganizationInvitations(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.Success(w, invitations)
+}
+
+func (s *HTTPServer) inviteOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	var req InviteOrganizationMemberRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	invitation, err := s.authService.InviteOrganizationMember(r.Context(), userID, chi.URLParam(r, "id"), req.Email, req.Role)
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.Created(w, invitation)
+}
+
+func (s *HTTPServer) revokeOrganizationInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	err := s.authService.RevokeOrganizationInvitation(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "invitationID"))
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Invitation revoked successfully")
+}
+
+func (s *HTTPServer) acceptOrganizationInvitation(w http.ResponseWriter, r *http.Request) {
+	var req OrganizationInvitationRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	org, err := s.authService.AcceptOrganizationInvitation(r.Context(), userID, req.Token)
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, org, "Invitation accepted successfully")
+}
+
+func (s *HTTPServer) declineOrganizationInvitation(w http.ResponseWriter, r *http.Request) {
+	var req OrganizationInvitationRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	if err := s.authService.DeclineOrganizationInvitation(r.Context(), userID, req.Token); err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Invitation declined successfully")
+}
+
+// switchOrganization issues new tokens for the caller's session with another active organization
+func (s *HTTPServer) switchOrganization(w http.ResponseWriter, r *http.Request) {
+	var req SwitchOrganizationRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	sessionID, _ := r.Context().Value("sessionID").(string)
+
+	token, refreshToken, err := s.authService.SwitchOrganization(r.Context(), userID, sessionID, req.OrganizationID)
+	if err != nil {
+		s.writeOrganizationError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"token":         token,
+		"refresh_token": refreshToken,
+	}, "Organization switched successfully")
+}
+
+// writeOrganizationError maps organization errors to HTTP responses
+func (s *HTTPServer) writeOrganizationError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrOrganizationNotFound, service.ErrOrganizationMemberNotFound, service.ErrInvalidInvitation:
+		response.NotFound(w, err.Error())
+	case service.ErrOrganizationForbidden, service.ErrInvitationEmailMismatch, service.ErrEmailNotVerified, service.ErrOrganizationClientToken:
+		response.Forbidden(w, err.Error())
+	case service.ErrInvalidOrganization, service.ErrInvalidOrganizationRole:
+		response.BadRequest(w, err.Error())
+	case service.ErrOrganizationSlugTaken, service.ErrAlreadyOrganizationMember:
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrAlreadyExists, err.Error()))
+	case service.ErrLastOrganizationOwner:
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrConflict, err.Error()))
+	case service.ErrSessionNotFound:
+		response.Unauthorized(w, "Switching organizations requires a session token")
+	default:
+		s.logger.Error("Organization request failed", "error", err)
+		response.Error(w, err)
+	}
+}
+
+func (s *HTTPServer) getUserRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := s.authService.GetUserRoles(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		s.writeRoleError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"roles": roles,
+	}, "User roles retrieved successfully")
+}
+
+func (s *HTTPServer) assignRole(w http.ResponseWriter, r *http.Request) {
+	var req AssignRoleRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	roles, err := s.authService.AssignRole(r.Context(), chi.URLParam(r, "id"), req.Role)
+	if err != nil {
+		s.writeRoleError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"roles": roles,
+	}, "Role assigned successfully")
+}
+
+func (s *HTTPServer) removeRole(w http.ResponseWriter, r *http.Request) {
+	roles, err := s.authService.RemoveRole(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "role"))
+	if err != nil {
+		s.writeRoleError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"roles": roles,
+	}, "Role removed successfully")
+}
+
+func (s *HTTPServer) writeRoleError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrUserNotFound, service.ErrRoleNotFound, service.ErrRoleNotAssigned:
+		response.NotFound(w, err.Error())
+	default:
+		s.logger.Error("Role management failed", "error", err)
+		response.Error(w, err)
+	}
+}
+
+func (s *HTTPServer) unlockAccount(w http.ResponseWriter, r *http.Request) {
+	if err := s.authService.UnlockAccount(r.Context(), chi.URLParam(r, "id")); err != nil {
+		if err == service.ErrUserNotFound {
+			response.NotFound(w, err.Error())
+			return
+		}
+		s.logger.Error("Unlock account failed", "error", err)
+		response.Error(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Account unlocked successfully")
+}
+
+func (s *HTTPServer) listAuditEvents(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := service.AuditQuery{
+		EventType: params.Get("event_type"),
+		Outcome:   params.Get("outcome"),
+		ActorID:   params.Get("actor_id"),
+		SubjectID: params.Get("subject_id"),
+	}
+
+	var err error
+	if query.From, err = parseTimeParam(params.Get("from")); err != nil {
+		response.BadRequest(w, "from must be an RFC 3339 timestamp")
+		return
+	}
+	if query.To, err = parseTimeParam(params.Get("to")); err != nil {
+		response.BadRequest(w, "to must be an RFC 3339 timestamp")
+		return
+	}
+	if query.Page, err = parseIntParam(params.Get("page")); err != nil {
+		response.BadRequest(w, "page must be a number")
+		return
+	}
+	if query.PageSize, err = parseIntParam(params.Get("page_size")); err != nil {
+		response.BadRequest(w, "page_size must be a number")
+		return
+	}
+
+	events, pagination, err := s.authService.ListAuditEvents(r.Context(), query)
+	if err != nil {
+		s.logger.Error("List audit events failed", "error", err)
+		response.Error(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"events":     events,
+		"pagination": pagination,
+	}, "Audit events retrieved successfully")
+}
+
+func (s *HTTPServer) listUsers(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := service.UserQuery{
+		Search: params.Get("search"),
+		Email:  params.Get("email"),
+		Roles:  params["role"],
+	}
+
+	if value := params.Get("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			response.BadRequest(w, "active must be true or false")
+			return
+		}
+		query.Active = &active
+	}
+	if sortBy := params.Get("sort_by"); sortBy != "" {
+		query.Sorts = []service.UserSort{{Field: sortBy, Descending: params.Get("sort_order") == "desc"}}
+	}
+
+	var err error
+	if query.Page, err = parseIntParam(params.Get("page")); err != nil {
+		response.BadRequest(w, "page must be a number")
+		return
+	}
+	if query.PageSize, err = parseIntParam(params.Get("page_size")); err != nil {
+		response.BadRequest(w, "page_size must be a number")
+		return
+	}
+
+	users, pagination, err := s.authService.ListUsers(r.Context(), query)
+	if err != nil {
+		s.writeUserAdminError(w, err)
+		return
+	}
+
+	userResponses := make([]*UserResponse, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, convertToUserResponse(user))
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"users":      userResponses,
+		"pagination": pagination,
+	}, "Users retrieved successfully")
+}
+
+func (s *HTTPServer) getUser(w http.ResponseWriter, r *http.Request) {
+	user, err := s.authService.GetUser(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		s.writeUserAdminError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"user": convertToUserResponse(user),
+	}, "User retrieved successfully")
+}
+
+func (s *HTTPServer) updateUser(w http.ResponseWriter, r *http.Request) {
+	var req UpdateUserRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	user, err := s.authService.UpdateUser(r.Context(), chi.URLParam(r, "id"), service.UserUpdate{
+		Email:         req.Email,
+		FirstName:     req.FirstName,
+		LastName:      req.LastName,
+		EmailVerified: req.EmailVerified,
+	})
+	if err != nil {
+		s.writeUserAdminError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"user": convertToUserResponse(user),
+	}, "User updated successfully")
+}
+
+func (s *HTTPServer) deactivateUser(w http.ResponseWriter, r *http.Request) {
+	if err := s.authService.DeactivateUser(r.Context(), chi.URLParam(r, "id")); err != nil {
+		s.writeUserAdminError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "User deactivated successfully")
+}
+
+func (s *HTTPServer) reactivateUser(w http.ResponseWriter, r *http.Request) {
+	if err := s.authService.ReactivateUser(r.Context(), chi.URLParam(r, "id")); err != nil {
+		s.writeUserAdminError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "User reactivated successfully")
+}
+
+func (s *HTTPServer) forceLogout(w http.ResponseWriter, r *http.Request) {
+	count, err := s.authService.ForceLogout(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		s.writeUserAdminError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"revoked_count": count,
+	}, "User logged out successfully")
+}
+
+func (s *HTTPServer) resetMFA(w http.ResponseWriter, r *http.Request) {
+	if err := s.authService.ResetMFA(r.Context(), chi.URLParam(r, "id")); err != nil {
+		s.writeUserAdminError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Two-factor authentication reset successfully")
+}
+
+func (s *HTTPServer) impersonateUser(w http.ResponseWriter, r *http.Request) {
+	var req ImpersonateUserRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	actorID := r.Context().Value("userID").(string)
+	impersonation, err := s.authService.ImpersonateUser(r.Context(), actorID, chi.URLParam(r, "id"), req.Reason)
+	if err != nil {
+		s.writeUserAdminError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"token":      impersonation.AccessToken,
+		"expires_at": impersonation.ExpiresAt,
+		"user":       convertToUserResponse(impersonation.User),
+	}, "Impersonation started")
+}
+
+func (s *HTTPServer) writeUserAdminError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		response.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrEmailTaken):
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrAlreadyExists, err.Error()))
+	case errors.Is(err, service.ErrUserModified):
+		response.Error(w, commonErrors.NewAppError(commonErrors.ErrConflict, err.Error()))
+	case errors.Is(err, service.ErrInvalidUserQuery), errors.Is(err, service.ErrCannotDeactivateSelf),
+		errors.Is(err, service.ErrImpersonationReason):
+		response.BadRequest(w, err.Error())
+	case errors.Is(err, service.ErrCannotImpersonate), errors.Is(err, service.ErrNotAllowedOnImpersonation):
+		response.Forbidden(w, err.Error())
+	default:
+		s.logger.Error("User administration failed", "error", err)
+		response.Error(w, err)
+	}
+}
+
+func (s *HTTPServer) listServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := s.authService.ListServiceAccounts(r.Context())
+	if err != nil {
+		s.writeServiceAccountError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"service_accounts": accounts,
+	}, "Service accounts retrieved successfully")
+}
+
+func (s *HTTPServer) createServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var req CreateServiceAccountRequest
+	if err := validation.DecodeJSON(w, r, &req); err != nil {
+		validation.WriteError(w, err)
+		return
+	}
+
+	account, secret, err := s.authService.CreateServiceAccount(r.Context(), req.Name, req.Scopes)
+	if err != nil {
+		s.writeServiceAccountError(w, err)
+		return
+	}
+
+	response.Created(w, map[string]interface{}{
+		"service_account": account,
+		"client_secret":   secret,
+	})
+}
+
+func (s *HTTPServer) rotateServiceAccountSecret(w http.ResponseWriter, r *http.Request) {
+	secret, err := s.authService.RotateServiceAccountSecret(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		s.writeServiceAccountError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"client_secret": secret,
+	}, "Service account secret rotated successfully")
+}
+
+func (s *HTTPServer) deleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	if err := s.authService.DeleteServiceAccount(r.Context(), chi.URLParam(r, "id")); err != nil {
+		s.writeServiceAccountError(w, err)
+		return
+	}
+
+	response.SuccessWithMessage(w, nil, "Service account deleted successfully")
+}
+
+func (s *HTTPServer) writeServiceAccountError(w http.ResponseWriter, err error) {
+	switch {
+	case err == service.ErrServiceAccountNotFound:
+		response.NotFound(w, err.Error())
+	case errors.Is(err, service.ErrInvalidScope):
+		response.BadRequest(w, err.Error())
+	default:
+		s.logger.Error("Service account management failed", "error", err)
+		response.Error(w, err)
+	}
+}
+
+// jwks serves the public signing keys as a plain JSON Web Key Set, as expected by JWT libraries
+func (s *HTTPServer) jwks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(s.keys.JWKS())
+}
+
+func (s *HTTPServer) health(w http.ResponseWriter, r *http.Request) {
+	response.SuccessWithMessage(w, map[string]interface{}{
+		"service":   "auth",
+		"timestamp": time.Now().Unix(),
+	}, "Service is healthy")
+}
+
+func (s *HTTPServer) Start() error {
+	s.logger.Info("Starting HTTP server", "addr", s.server.Addr)
+	return s.server.ListenAndServe()
+}
 
 func (s *HTTPServer) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping HTTP server")
 	return s.server.Shutdown(ctx)
 }
 
+// bearerToken extracts the token from the Authorization header, removing the "Bearer " prefix if present
+// parseTimeParam parses an optional RFC 3339 query parameter
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseIntParam parses an optional integer query parameter
+func parseIntParam(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// writePasswordPolicyError answers with the rules a rejected new password in field breaks and
+// reports whether err was a password policy error
+func writePasswordPolicyError(w http.ResponseWriter, field string, err error) bool {
+	var policyErr *password.PolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+
+	details := make([]*commonpb.ErrorDetail, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		details = append(details, &commonpb.ErrorDetail{
+			Code:    violation.Rule,
+			Message: violation.Message,
+			Field:   field,
+		})
+	}
+	response.ValidationError(w, "Password does not meet the password policy", details)
+	return true
+}
+
+func bearerToken(r *http.Request) string {
+	token := r.Header.Get("Authorization")
+	if len(token) > 7 && strings.ToLower(token[:7]) == "bearer " {
+		token = token[7:]
+	}
+	return token
+}
+
+// requestCredential returns the personal API key or the bearer token a request authenticates with
+func requestCredential(r *http.Request) string {
+	if key := r.Header.Get(authMiddleware.HeaderAPIKey); key != "" {
+		return key
+	}
+	if token := r.Header.Get("Authorization"); len(token) > 7 && strings.ToLower(token[:7]) == "apikey " {
+		return token[7:]
+	}
+	return bearerToken(r)
+}
+
 func convertToUserResponse(user *service.User) *UserResponse {
 	if user == nil {
 		return nil
 	}
 	
 	return &UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Roles:     []string{}, // No roles in simplified model
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-		Active:    user.Active,
+		ID:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Roles:         user.Roles,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		Active:        user.Active,
+		EmailVerified: user.EmailVerified,
 	}
 }
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+// loginPage is the minimal sign-in form shown by the authorization endpoint
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Sign in</title></head>
+<body>
+<h1>Sign in</h1>
+{{if .Error}}<p>{{.Error}}</p>{{end}}
+<form method="post" action="/oauth2/authorize">
+<input type="hidden" name="client_id" value="{{.Request.ClientID}}">
+<input type="hidden" name="redirect_uri" value="{{.Request.RedirectURI}}">
+<input type="hidden" name="response_type" value="{{.Request.ResponseType}}">
+<input type="hidden" name="scope" value="{{.Request.Scope}}">
+<input type="hidden" name="state" value="{{.Request.State}}">
+<input type="hidden" name="nonce" value="{{.Request.Nonce}}">
+<input type="hidden" name="code_challenge" value="{{.Request.CodeChallenge}}">
+<input type="hidden" name="code_challenge_method" value="{{.Request.CodeChallengeMethod}}">
+<label>Email <input type="email" name="email" required></label>
+<label>Password <input type="password" name="password" required></label>
+<label>Authentication code (if two-factor authentication is enabled) <input type="text" name="mfa_code" autocomplete="one-time-code"></label>
+<button type="submit">Sign in</button>
+</form>
+</body>
+</html>
+`))
+
+type loginPageData struct {
+	Request *service.AuthorizationRequest
+	Error   string
+}
+
+// setupOIDCRoutes registers the OpenID Connect provider endpoints
+func (s *HTTPServer) setupOIDCRoutes(r chi.Router) {
+	r.Get("/.well-known/openid-configuration", s.openIDConfiguration)
+	r.Get("/oauth2/authorize", s.authorize)
+	r.Post("/oauth2/authorize", s.authorize)
+	r.Get("/userinfo", s.userInfo)
+	r.Post("/userinfo", s.userInfo)
+}
+
+func (s *HTTPServer) openIDConfiguration(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(s.authService.Discovery())
+}
+
+// authorize shows the sign-in form on GET and issues an authorization code on a successful POST
+func (s *HTTPServer) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	req := &service.AuthorizationRequest{
+		ClientID:            r.Form.Get("client_id"),
+		RedirectURI:         r.Form.Get("redirect_uri"),
+		ResponseType:        r.Form.Get("response_type"),
+		Scope:               r.Form.Get("scope"),
+		State:               r.Form.Get("state"),
+		Nonce:               r.Form.Get("nonce"),
+		CodeChallenge:       r.Form.Get("code_challenge"),
+		CodeChallengeMethod: r.Form.Get("code_challenge_method"),
+	}
+
+	if r.Method == http.MethodGet {
+		if err := s.authService.ValidateAuthorizationRequest(r.Context(), req); err != nil {
+			s.writeAuthorizeError(w, r, req, err)
+			return
+		}
+		s.renderLoginPage(w, req, "")
+		return
+	}
+
+	code, err := s.authService.Authorize(r.Context(), req, r.PostForm.Get("email"), r.PostForm.Get("password"), r.PostForm.Get("mfa_code"))
+	if err != nil {
+		switch err {
+		case service.ErrInvalidCredentials:
+			s.renderLoginPage(w, req, "Invalid email or password")
+			return
+		case service.ErrMFARequired, service.ErrInvalidMFACode:
+			s.renderLoginPage(w, req, "Enter a valid code from your authenticator app or a recovery code")
+			return
+		case service.ErrAccountLocked, service.ErrLoginThrottled:
+			s.renderLoginPage(w, req, "Too many failed sign-in attempts, try again later")
+			return
+		}
+		s.writeAuthorizeError(w, r, req, err)
+		return
+	}
+
+	params := url.Values{"code": {code}}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	http.Redirect(w, r, redirectURL(req.RedirectURI, params), http.StatusFound)
+}
+
+// writeAuthorizeError redirects protocol errors back to the client; errors about the client
+// or redirect URI are shown to the user instead, since the redirect target cannot be trusted
+func (s *HTTPServer) writeAuthorizeError(w http.ResponseWriter, r *http.Request, req *service.AuthorizationRequest, err error) {
+	oauthErr, ok := err.(*service.OAuthError)
+	if !ok {
+		switch err {
+		case service.ErrInvalidClient, service.ErrInvalidRedirectURI:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.logger.Error("Authorization failed", "error", err, "client_id", req.ClientID)
+		oauthErr = &service.OAuthError{Code: "server_error", Description: "authorization failed"}
+	}
+
+	params := url.Values{
+		"error":             {oauthErr.Code},
+		"error_description": {oauthErr.Description},
+	}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	http.Redirect(w, r, redirectURL(req.RedirectURI, params), http.StatusFound)
+}
+
+func (s *HTTPServer) renderLoginPage(w http.ResponseWriter, req *service.AuthorizationRequest, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	if message != "" {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	loginPage.Execute(w, loginPageData{Request: req, Error: message})
+}
+
+// token implements the OAuth2 token endpoint. The client_credentials grant is always available
+// to service accounts; authorization_code and refresh_token require the OpenID Connect provider.
+func (s *HTTPServer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, &service.OAuthError{Code: "invalid_request", Description: "malformed form body"})
+		return
+	}
+
+	clientID, clientSecret := clientCredentials(r)
+
+	var (
+		resp *service.TokenResponse
+		err  error
+	)
+	grantType := r.PostForm.Get("grant_type")
+	if grantType != "client_credentials" && !s.authService.OIDCEnabled() {
+		grantType = ""
+	}
+
+	switch grantType {
+	case "client_credentials":
+		resp, err = s.authService.ClientCredentialsToken(r.Context(), clientID, clientSecret, r.PostForm.Get("scope"))
+	case "authorization_code":
+		resp, err = s.authService.ExchangeAuthorizationCode(r.Context(), clientID, clientSecret,
+			r.PostForm.Get("code"), r.PostForm.Get("redirect_uri"), r.PostForm.Get("code_verifier"))
+	case "refresh_token":
+		resp, err = s.authService.ExchangeRefreshToken(r.Context(), clientID, clientSecret, r.PostForm.Get("refresh_token"))
+	default:
+		err = &service.OAuthError{Code: "unsupported_grant_type", Description: "grant type is not supported"}
+	}
+
+	if err != nil {
+		s.writeClientError(w, err, clientID, "token request failed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// introspect implements the RFC 7662 token introspection endpoint for resource servers
+func (s *HTTPServer) introspect(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("token") == "" {
+		writeOAuthError(w, http.StatusBadRequest, &service.OAuthError{Code: "invalid_request", Description: "token parameter required"})
+		return
+	}
+
+	clientID, clientSecret := clientCredentials(r)
+
+	resp, err := s.authService.Introspect(r.Context(), clientID, clientSecret, r.PostForm.Get("token"))
+	if err != nil {
+		s.writeClientError(w, err, clientID, "introspection failed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeClientError writes an error of a client-authenticated OAuth2 endpoint; unexpected errors are logged
+// and reported as server_error with description
+func (s *HTTPServer) writeClientError(w http.ResponseWriter, err error, clientID, description string) {
+	oauthErr, ok := err.(*service.OAuthError)
+	if !ok {
+		s.logger.Error("OAuth2 request failed", "error", err, "client_id", clientID)
+		writeOAuthError(w, http.StatusInternalServerError, &service.OAuthError{Code: "server_error", Description: description})
+		return
+	}
+
+	status := http.StatusBadRequest
+	if oauthErr.Code == "invalid_client" {
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+	}
+	writeOAuthError(w, status, oauthErr)
+}
+
+func (s *HTTPServer) userInfo(w http.ResponseWriter, r *http.Request) {
+	claims, err := s.authService.UserInfo(r.Context(), bearerToken(r))
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(claims)
+}
+
+// clientCredentials reads client credentials from HTTP Basic authentication or, failing that, the form body
+func clientCredentials(r *http.Request) (string, string) {
+	if clientID, clientSecret, ok := r.BasicAuth(); ok {
+		return clientID, clientSecret
+	}
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+}
+
+// writeOAuthError writes an RFC 6749 error response
+func writeOAuthError(w http.ResponseWriter, status int, err *service.OAuthError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":             err.Code,
+		"error_description": err.Description,
+	})
+}
+
+// redirectURL appends query parameters to a registered redirect URI
+func redirectURL(base string, params url.Values) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
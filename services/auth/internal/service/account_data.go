@@ -0,0 +1,358 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/mail"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+var (
+	ErrDeletionNotScheduled = errors.New("account deletion is not scheduled or can no longer be cancelled")
+	ErrInvalidExportFormat  = errors.New("export format must be zip or json")
+)
+
+// Account export formats
+const (
+	ExportFormatZip  = "zip"
+	ExportFormatJSON = "json"
+)
+
+// accountDeletionBatchSize is the number of due accounts deleted per run of the deletion job
+const accountDeletionBatchSize = 100
+
+// AccountDataProvider is implemented by other services that store data about users, such as the
+// feed service. Account exports include the data a provider returns, and account deletion asks
+// every provider to delete its data before the user is deleted.
+type AccountDataProvider interface {
+	// Name identifies the provider's data in exports, e.g. "feed"
+	Name() string
+	// ExportUserData returns the user's data, which is marshaled to JSON
+	ExportUserData(ctx context.Context, userID string) (interface{}, error)
+	// DeleteUserData irreversibly deletes or anonymizes the user's data. It is retried on the next
+	// run of the deletion job if it fails, so it must be safe to call again.
+	DeleteUserData(ctx context.Context, userID string) error
+}
+
+// RegisterAccountDataProvider adds another service's user data to account exports and deletions
+func (s *AuthService) RegisterAccountDataProvider(provider AccountDataProvider) {
+	s.dataProviders = append(s.dataProviders, provider)
+}
+
+// AccountExport is everything the service and its data providers store about a user
+type AccountExport struct {
+	UserID      string
+	GeneratedAt time.Time
+	// Sections are the parts of the export, such as "profile" and "sessions"
+	Sections []ExportSection
+}
+
+// ExportSection is one part of an account export, written as <Name>.json in archives
+type ExportSection struct {
+	Name string
+	Data interface{}
+}
+
+// exportProfile is the profile section of an account export
+type exportProfile struct {
+	*User
+	MFAEnabled          bool       `json:"mfa_enabled"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+}
+
+// ExportAccountData collects the personal data of a user for download
+func (s *AuthService) ExportAccountData(ctx context.Context, userID string) (*AccountExport, error) {
+	if err := impersonationAllowed(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mfaEnabled, err := s.mfaEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deletionScheduledAt, err := s.userRepo.GetDeletionSchedule(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deletion schedule: %w", err)
+	}
+
+	sessions, err := s.ListSessions(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys, err := s.ListPasskeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := s.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := s.ListOrganizations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoEvents, err := s.auditRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*AuditEvent, 0, len(repoEvents))
+	for _, event := range repoEvents {
+		events = append(events, toAuditEvent(event))
+	}
+
+	export := &AccountExport{
+		UserID:      userID,
+		GeneratedAt: time.Now().UTC(),
+		Sections: []ExportSection{
+			{Name: "profile", Data: exportProfile{User: user, MFAEnabled: mfaEnabled, DeletionScheduledAt: deletionScheduledAt}},
+			{Name: "sessions", Data: sessions},
+			{Name: "passkeys", Data: passkeys},
+			{Name: "api_keys", Data: apiKeys},
+			{Name: "organizations", Data: orgs},
+			{Name: "audit_events", Data: events},
+		},
+	}
+
+	for _, provider := range s.dataProviders {
+		data, err := provider.ExportUserData(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s data: %w", provider.Name(), err)
+		}
+		export.Sections = append(export.Sections, ExportSection{Name: provider.Name(), Data: data})
+	}
+
+	s.audit(ctx, AuditAccountExport, AuditSuccess, userID, nil)
+	return export, nil
+}
+
+// ExportFile is an encoded account export, ready for download
+type ExportFile struct {
+	Data        []byte
+	ContentType string
+	Filename    string
+}
+
+// Encode encodes the export in one of the export formats, ExportFormatZip if format is empty
+func (e *AccountExport) Encode(format string) (*ExportFile, error) {
+	var buf bytes.Buffer
+	file := &ExportFile{}
+
+	switch format {
+	case ExportFormatZip, "":
+		format = ExportFormatZip
+		file.ContentType = "application/zip"
+		if err := e.writeZip(&buf); err != nil {
+			return nil, err
+		}
+	case ExportFormatJSON:
+		file.ContentType = "application/json"
+		if err := e.writeJSON(&buf); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrInvalidExportFormat
+	}
+
+	file.Data = buf.Bytes()
+	file.Filename = fmt.Sprintf("account-export-%s.%s", e.GeneratedAt.Format("20060102T150405Z"), format)
+	return file, nil
+}
+
+// writeJSON writes the export as a single JSON document with one field per section
+func (e *AccountExport) writeJSON(w io.Writer) error {
+	document := map[string]interface{}{
+		"user_id":      e.UserID,
+		"generated_at": e.GeneratedAt,
+	}
+	for _, section := range e.Sections {
+		document[section.Name] = section.Data
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+// writeZip writes the export as a ZIP archive with one JSON file per section
+func (e *AccountExport) writeZip(w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	for _, section := range e.Sections {
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     section.Name + ".json",
+			Method:   zip.Deflate,
+			Modified: e.GeneratedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", section.Name, err)
+		}
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(section.Data); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", section.Name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+// RequestAccountDeletion schedules the caller's account for deletion after the grace period and
+// returns when it will be deleted. Until then the account works normally and the deletion can be
+// cancelled with CancelAccountDeletion.
+func (s *AuthService) RequestAccountDeletion(ctx context.Context, userID, currentPassword string) (time.Time, error) {
+	if err := impersonationAllowed(ctx); err != nil {
+		return time.Time{}, err
+	}
+
+	repoUser, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, ErrUserNotFound
+	}
+
+	if !s.verifyPassword(repoUser, currentPassword) {
+		s.audit(ctx, AuditAccountDeletion, AuditFailure, userID, map[string]string{"step": "request", "reason": "bad_password"})
+		return time.Time{}, ErrIncorrectPassword
+	}
+
+	scheduledAt := time.Now().Add(s.deletionGracePeriod).Truncate(time.Second)
+	if err := s.userRepo.ScheduleDeletion(ctx, userID, scheduledAt); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return time.Time{}, ErrUserNotFound
+		}
+		return time.Time{}, err
+	}
+	s.audit(ctx, AuditAccountDeletion, AuditSuccess, userID, map[string]string{"step": "request"})
+
+	// The deletion is scheduled either way; the notice only lets the owner cancel it in time
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      repoUser.Email,
+		Subject: "Your account is scheduled for deletion",
+		Body: fmt.Sprintf("Hi %s,\n\nYour account and its data will be permanently deleted on %s. Log in and cancel the deletion before then if you want to keep your account.\n\nIf you did not ask to delete your account, change your password immediately.\n",
+			repoUser.FirstName, scheduledAt.UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		s.logger.Error("Failed to send account deletion notice", "error", err, "user_id", userID)
+	}
+
+	s.logger.Info("Account deletion scheduled", "user_id", userID, "scheduled_at", scheduledAt)
+	return scheduledAt, nil
+}
+
+// CancelAccountDeletion keeps the caller's account if its deletion is not yet due
+func (s *AuthService) CancelAccountDeletion(ctx context.Context, userID string) error {
+	if err := s.userRepo.CancelDeletion(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrDeletionNotScheduled) {
+			return ErrDeletionNotScheduled
+		}
+		return err
+	}
+	s.audit(ctx, AuditAccountDeletion, AuditSuccess, userID, map[string]string{"step": "cancel"})
+
+	s.logger.Info("Account deletion cancelled", "user_id", userID)
+	return nil
+}
+
+// RunAccountDeletion deletes due accounts every interval until ctx is done
+func (s *AuthService) RunAccountDeletion(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if count, err := s.DeleteDueAccounts(ctx); err != nil {
+			s.logger.Error("Account deletion job failed", "error", err)
+		} else if count > 0 {
+			s.logger.Info("Deleted accounts", "count", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteDueAccounts irreversibly deletes a batch of accounts whose deletion grace period has
+// passed and returns how many were deleted. Accounts that fail to delete are retried next time.
+func (s *AuthService) DeleteDueAccounts(ctx context.Context) (int, error) {
+	ids, err := s.userRepo.ListDueForDeletion(ctx, accountDeletionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.deleteAccount(ctx, id); err != nil {
+			s.logger.Error("Failed to delete account", "error", err, "user_id", id)
+			continue
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// deleteAccount logs a user out everywhere, has every data provider delete its data, hands over
+// the user's organizations and then deletes the user itself
+func (s *AuthService) deleteAccount(ctx context.Context, userID string) error {
+	repoUser, err := s.userRepo.GetByIDIncludingInactive(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.RevokeAllSessions(ctx, userID, ""); err != nil {
+		return err
+	}
+
+	for _, provider := range s.dataProviders {
+		if err := provider.DeleteUserData(ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete %s data: %w", provider.Name(), err)
+		}
+	}
+
+	if err := s.orgRepo.HandOverOrganizations(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.HardDelete(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			// Deleted by another instance in the meantime
+			return nil
+		}
+		return err
+	}
+	s.audit(ctx, AuditAccountDeletion, AuditSuccess, userID, map[string]string{"step": "delete"})
+
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      repoUser.Email,
+		Subject: "Your account was deleted",
+		Body:    "Hi,\n\nAs requested, your account and its data have been permanently deleted.\n",
+	})
+	if err != nil {
+		s.logger.Error("Failed to send account deletion confirmation", "error", err, "user_id", userID)
+	}
+
+	s.logger.Info("Account deleted", "user_id", userID)
+	return nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+var (
+	ErrInvalidUserQuery     = errors.New("invalid user query")
+	ErrCannotDeactivateSelf = errors.New("administrators cannot deactivate their own account")
+)
+
+// User listing page sizes
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+)
+
+// UserSortFields are the fields users can be sorted by
+var UserSortFields = []string{"created_at", "updated_at", "email", "first_name", "last_name"}
+
+// UserQuery filters, sorts and pages the user list; empty fields match everyone
+type UserQuery struct {
+	Search   string // case-insensitive match on email, first or last name
+	Email    string
+	Roles    []string // users with any of these roles
+	Active   *bool
+	Sorts    []UserSort
+	Page     int
+	PageSize int
+}
+
+// UserSort orders the user list by one of UserSortFields
+type UserSort struct {
+	Field      string
+	Descending bool
+}
+
+// UserUpdate holds the fields an administrator changes; nil fields are left alone
+type UserUpdate struct {
+	Email         *string
+	FirstName     *string
+	LastName      *string
+	EmailVerified *bool
+}
+
+// ListUsers returns a page of matching users, active or not, newest first unless sorted otherwise
+func (s *AuthService) ListUsers(ctx context.Context, query UserQuery) ([]*User, *Pagination, error) {
+	page, pageSize := normalizePage(query.Page, query.PageSize, defaultUserPageSize, maxUserPageSize)
+
+	filter := repository.UserFilter{
+		Search: strings.TrimSpace(query.Search),
+		Email:  query.Email,
+		Roles:  query.Roles,
+		Active: query.Active,
+	}
+
+	sorts := make([]repository.UserSort, 0, len(query.Sorts))
+	for _, sort := range query.Sorts {
+		sorts = append(sorts, repository.UserSort{Field: sort.Field, Descending: sort.Descending})
+	}
+
+	repoUsers, total, err := s.userRepo.List(ctx, filter, sorts, pageSize, (page-1)*pageSize)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidUserSort) {
+			return nil, nil, fmt.Errorf("%w: users can be sorted by %s", ErrInvalidUserQuery, strings.Join(UserSortFields, ", "))
+		}
+		return nil, nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]*User, 0, len(repoUsers))
+	for _, repoUser := range repoUsers {
+		user := toUser(repoUser)
+		if user.Roles, err = s.roleRepo.GetUserRoles(ctx, user.ID); err != nil {
+			return nil, nil, fmt.Errorf("failed to load user roles: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, newPagination(page, pageSize, total), nil
+}
+
+// GetUser returns a user with roles and permissions loaded, whether or not the account is active
+func (s *AuthService) GetUser(ctx context.Context, userID string) (*User, error) {
+	repoUser, err := s.userRepo.GetByIDIncludingInactive(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	user := toUser(repoUser)
+	if err := s.loadAuthorization(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to load user roles: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdateUser changes a user's email, name or email verification status. A new email address is
+// unverified unless update says otherwise.
+func (s *AuthService) UpdateUser(ctx context.Context, userID string, update UserUpdate) (*User, error) {
+	repoUser, err := s.userRepo.GetByIDIncludingInactive(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	changed := map[string]string{}
+	if update.Email != nil && *update.Email != repoUser.Email {
+		exists, err := s.userRepo.EmailExists(ctx, *update.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check email existence: %w", err)
+		}
+		if exists {
+			return nil, ErrEmailTaken
+		}
+		repoUser.Email = *update.Email
+		repoUser.EmailVerified = false
+		changed["email"] = "changed"
+	}
+	if update.FirstName != nil {
+		repoUser.FirstName = *update.FirstName
+		changed["first_name"] = "changed"
+	}
+	if update.LastName != nil {
+		repoUser.LastName = *update.LastName
+		changed["last_name"] = "changed"
+	}
+	if update.EmailVerified != nil {
+		repoUser.EmailVerified = *update.EmailVerified
+		changed["email_verified"] = fmt.Sprint(*update.EmailVerified)
+	}
+
+	if err := s.userRepo.Update(ctx, repoUser); err != nil {
+		return nil, mapUserUpdateError(err)
+	}
+	s.audit(ctx, AuditUserUpdate, AuditSuccess, userID, changed)
+
+	return s.GetUser(ctx, userID)
+}
+
+// DeactivateUser disables a user's account and revokes all of their sessions. Deactivated users
+// cannot log in until they are reactivated.
+func (s *AuthService) DeactivateUser(ctx context.Context, userID string) error {
+	if callerID, _ := ctx.Value("userID").(string); callerID == userID {
+		return ErrCannotDeactivateSelf
+	}
+
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if _, err := s.RevokeAllSessions(ctx, userID, ""); err != nil {
+		return err
+	}
+	s.audit(ctx, AuditUserDeactivate, AuditSuccess, userID, nil)
+
+	s.logger.Info("User deactivated", "user_id", userID)
+	return nil
+}
+
+// ReactivateUser re-enables a deactivated account
+func (s *AuthService) ReactivateUser(ctx context.Context, userID string) error {
+	if err := s.userRepo.Reactivate(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	s.audit(ctx, AuditUserReactivate, AuditSuccess, userID, nil)
+
+	s.logger.Info("User reactivated", "user_id", userID)
+	return nil
+}
+
+// ForceLogout revokes every session of a user and returns the number of revoked sessions
+func (s *AuthService) ForceLogout(ctx context.Context, userID string) (int, error) {
+	if _, err := s.userRepo.GetByIDIncludingInactive(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return 0, ErrUserNotFound
+		}
+		return 0, err
+	}
+
+	count, err := s.RevokeAllSessions(ctx, userID, "")
+	if err != nil {
+		return 0, err
+	}
+	s.audit(ctx, AuditForceLogout, AuditSuccess, userID, map[string]string{"sessions": fmt.Sprint(count)})
+
+	return count, nil
+}
+
+// ResetMFA turns two-factor authentication off for a user who lost their authenticator and
+// recovery codes. They can log in with their password alone and enroll again.
+func (s *AuthService) ResetMFA(ctx context.Context, userID string) error {
+	if _, err := s.userRepo.GetByIDIncludingInactive(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to reset two-factor authentication: %w", err)
+	}
+	s.audit(ctx, AuditMFAReset, AuditSuccess, userID, nil)
+
+	s.logger.Info("Two-factor authentication reset", "user_id", userID)
+	return nil
+}
+
+// toUser converts a repository user to a service user, without roles or password
+func toUser(repoUser *repository.User) *User {
+	return &User{
+		ID:            repoUser.ID,
+		Email:         repoUser.Email,
+		FirstName:     repoUser.FirstName,
+		LastName:      repoUser.LastName,
+		CreatedAt:     repoUser.CreatedAt,
+		UpdatedAt:     repoUser.UpdatedAt,
+		Active:        repoUser.Active,
+		EmailVerified: repoUser.EmailVerified,
+	}
+}
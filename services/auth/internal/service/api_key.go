@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+var (
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrInvalidAPIKey       = errors.New("invalid or expired api key")
+	ErrInvalidAPIKeyExpiry = errors.New("api key expiry must be in the future")
+	ErrAPIKeyNotAllowed    = errors.New("api keys can only be managed with a first-party session")
+)
+
+// APIKeyPrefix starts every personal API key, so leaked keys are easy to recognize
+const APIKeyPrefix = "gfk_"
+
+// apiKeyTouchInterval limits how often the last use of an API key is written to the database
+const apiKeyTouchInterval = time.Minute
+
+var apiKeyIDEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// APIKey is a long-lived credential a user creates for scripts. Its scopes are permission names
+// and bound what requests authenticated with it may do.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+}
+
+// APIKeyPrincipal is the user a request authenticated with an API key acts as. User has no roles,
+// and only those scopes of the key as permissions that the user still has.
+type APIKeyPrincipal struct {
+	KeyID  string
+	User   *User
+	Scopes []string
+}
+
+// IsAPIKey reports whether credential looks like a personal API key rather than a token
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
+
+// CreateAPIKey creates an API key for a user, limited to scopes out of the user's own permissions,
+// and returns it with the key itself. The key is only stored hashed and cannot be retrieved later.
+// A nil expiresAt creates a key that never expires.
+func (s *AuthService) CreateAPIKey(ctx context.Context, userID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	if err := apiKeyManagementAllowed(ctx); err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidAPIKeyExpiry
+	}
+
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, scope := range scopes {
+		if !contains(user.Permissions, scope) {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	key, prefix, err := generateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	repoKey := &repository.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashOneTimeToken(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.apiKeyRepo.Create(ctx, repoKey); err != nil {
+		return nil, "", fmt.Errorf("failed to create api key: %w", err)
+	}
+	s.audit(ctx, AuditAPIKeyCreate, AuditSuccess, userID, map[string]string{"api_key_id": repoKey.ID, "scopes": strings.Join(scopes, " ")})
+
+	s.logger.Info("API key created", "user_id", userID, "api_key_id", repoKey.ID)
+	return convertAPIKey(repoKey), key, nil
+}
+
+// ListAPIKeys returns the unrevoked API keys of a user, including expired ones
+func (s *AuthService) ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
+	repoKeys, err := s.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*APIKey, 0, len(repoKeys))
+	for _, repoKey := range repoKeys {
+		keys = append(keys, convertAPIKey(repoKey))
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key of a user so it can no longer authenticate
+func (s *AuthService) RevokeAPIKey(ctx context.Context, userID, id string) error {
+	if err := apiKeyManagementAllowed(ctx); err != nil {
+		return err
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, userID, id); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+	s.audit(ctx, AuditAPIKeyRevoke, AuditSuccess, userID, map[string]string{"api_key_id": id})
+
+	s.logger.Info("API key revoked", "user_id", userID, "api_key_id", id)
+	return nil
+}
+
+// AuthenticateAPIKey resolves an API key to the user it acts for and records its use
+func (s *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (*APIKeyPrincipal, error) {
+	prefix, ok := apiKeyPrefix(key)
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+
+	repoKey, err := s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(repoKey.KeyHash), []byte(hashOneTimeToken(key))) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+	if repoKey.ExpiresAt != nil && !repoKey.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	// The key can do no more than its owner currently can
+	user, err := s.GetProfile(ctx, repoKey.UserID)
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+	permissions := []string{}
+	for _, scope := range repoKey.Scopes {
+		if contains(user.Permissions, scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+	user.Roles = []string{}
+	user.Permissions = permissions
+
+	now := time.Now()
+	if repoKey.LastUsedAt == nil || now.Sub(*repoKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, repoKey.ID, ClientInfoFromContext(ctx).IPAddress, now); err != nil {
+			s.logger.Warn("Failed to record API key use", "error", err, "api_key_id", repoKey.ID)
+		}
+	}
+
+	return &APIKeyPrincipal{
+		KeyID:  repoKey.ID,
+		User:   user,
+		Scopes: repoKey.Scopes,
+	}, nil
+}
+
+// apiKeyManagementAllowed keeps a leaked API key from creating or revoking other keys, a
+// third-party client from minting keys beyond the scope the user consented to, and
+// administrators from creating keys for a user they impersonate
+func apiKeyManagementAllowed(ctx context.Context) error {
+	if keyID, _ := ctx.Value("apiKeyID").(string); keyID != "" {
+		return ErrAPIKeyNotAllowed
+	}
+	if clientID, _ := ctx.Value("clientID").(string); clientID != "" {
+		return ErrAPIKeyNotAllowed
+	}
+	return impersonationAllowed(ctx)
+}
+
+// generateAPIKey returns a random API key and its prefix. Keys look like gfk_<id>_<secret>, where
+// gfk_<id> is the prefix shown in listings and used to look the key up.
+func generateAPIKey() (string, string, error) {
+	id := make([]byte, 10)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	prefix := APIKeyPrefix + strings.ToLower(apiKeyIDEncoding.EncodeToString(id))
+	return prefix + "_" + base64.RawURLEncoding.EncodeToString(secret), prefix, nil
+}
+
+// apiKeyPrefix returns the prefix of a key generated by generateAPIKey
+func apiKeyPrefix(key string) (string, bool) {
+	if !IsAPIKey(key) {
+		return "", false
+	}
+	i := strings.Index(key[len(APIKeyPrefix):], "_")
+	if i <= 0 {
+		return "", false
+	}
+	return key[:len(APIKeyPrefix)+i], true
+}
+
+func convertAPIKey(key *repository.APIKey) *APIKey {
+	return &APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		LastUsedIP: key.LastUsedIP,
+	}
+}
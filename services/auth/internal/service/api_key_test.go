@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAPIKeyManagementAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   error
+	}{
+		{name: "first-party session", values: map[string]string{"userID": "user-1", "sessionID": "session-1"}},
+		{name: "api key", values: map[string]string{"userID": "user-1", "apiKeyID": "key-1"}, want: ErrAPIKeyNotAllowed},
+		{name: "oidc client token", values: map[string]string{"userID": "user-1", "sessionID": "session-1", "clientID": "client-1"}, want: ErrAPIKeyNotAllowed},
+		{name: "impersonation", values: map[string]string{"userID": "user-1", "actorID": "admin-1"}, want: ErrNotAllowedOnImpersonation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			for key, value := range tt.values {
+				ctx = context.WithValue(ctx, key, value)
+			}
+
+			if err := apiKeyManagementAllowed(ctx); err != tt.want {
+				t.Errorf("apiKeyManagementAllowed() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
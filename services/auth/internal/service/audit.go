@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+// Audited event types
+const (
+	AuditLogin           = "login"
+	AuditRegister        = "register"
+	AuditTokenRefresh    = "token_refresh"
+	AuditLogout          = "logout"
+	AuditPasswordChange  = "password_change"
+	AuditRoleAssign      = "role_assign"
+	AuditRoleRemove      = "role_remove"
+	AuditAccountUnlock   = "account_unlock"
+	AuditUserUpdate      = "user_update"
+	AuditUserDeactivate  = "user_deactivate"
+	AuditUserReactivate  = "user_reactivate"
+	AuditForceLogout     = "force_logout"
+	AuditMFAReset        = "mfa_reset"
+	AuditProfileUpdate   = "profile_update"
+	AuditEmailChange     = "email_change"
+	AuditAccountExport   = "account_export"
+	AuditAccountDeletion = "account_deletion"
+	AuditAPIKeyCreate    = "api_key_create"
+	AuditAPIKeyRevoke    = "api_key_revoke"
+
+	AuditOrganizationCreate       = "organization_create"
+	AuditOrganizationDelete       = "organization_delete"
+	AuditOrganizationInvite       = "organization_invite"
+	AuditOrganizationMemberUpdate = "organization_member_update"
+	AuditOrganizationMemberRemove = "organization_member_remove"
+	AuditOrganizationSwitch       = "organization_switch"
+
+	AuditImpersonationStart = "impersonation_start"
+	AuditImpersonationStop  = "impersonation_stop"
+)
+
+// Audit event outcomes
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// Audit log page sizes
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+// AuditEvent is a recorded security event. ActorID is the user who acted and SubjectID the user
+// acted upon; they differ when an administrator acts on someone else's account.
+type AuditEvent struct {
+	ID        string            `json:"id"`
+	EventType string            `json:"event_type"`
+	Outcome   string            `json:"outcome"`
+	ActorID   string            `json:"actor_id,omitempty"`
+	SubjectID string            `json:"subject_id,omitempty"`
+	IPAddress string            `json:"ip_address,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// AuditQuery filters and pages the audit log; empty fields match every event
+type AuditQuery struct {
+	EventType string
+	Outcome   string
+	ActorID   string
+	SubjectID string
+	From      time.Time
+	To        time.Time
+	Page      int
+	PageSize  int
+}
+
+// ListAuditEvents returns a page of matching audit events, newest first
+func (s *AuthService) ListAuditEvents(ctx context.Context, query AuditQuery) ([]*AuditEvent, *Pagination, error) {
+	page, pageSize := normalizePage(query.Page, query.PageSize, defaultAuditPageSize, maxAuditPageSize)
+
+	filter := repository.AuditFilter{
+		EventType: query.EventType,
+		Outcome:   query.Outcome,
+		ActorID:   query.ActorID,
+		SubjectID: query.SubjectID,
+		From:      query.From,
+		To:        query.To,
+	}
+
+	repoEvents, total, err := s.auditRepo.List(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make([]*AuditEvent, 0, len(repoEvents))
+	for _, event := range repoEvents {
+		events = append(events, toAuditEvent(event))
+	}
+
+	return events, newPagination(page, pageSize, total), nil
+}
+
+func toAuditEvent(event *repository.AuditEvent) *AuditEvent {
+	return &AuditEvent{
+		ID:        event.ID,
+		EventType: event.EventType,
+		Outcome:   event.Outcome,
+		ActorID:   event.ActorID,
+		SubjectID: event.SubjectID,
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		RequestID: event.RequestID,
+		Details:   event.Details,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+// audit records a security event about subjectID. The actor is the authenticated caller, or the
+// subject itself for unauthenticated flows such as login. While an administrator impersonates a
+// user, the administrator is the actor. A failure to record is logged rather than failing the
+// audited operation.
+func (s *AuthService) audit(ctx context.Context, eventType, outcome, subjectID string, details map[string]string) {
+	actorID, _ := ctx.Value("userID").(string)
+	if impersonatorID, _ := ctx.Value("actorID").(string); impersonatorID != "" {
+		impersonated := make(map[string]string, len(details)+1)
+		for key, value := range details {
+			impersonated[key] = value
+		}
+		impersonated["impersonated_user_id"] = actorID
+		actorID, details = impersonatorID, impersonated
+	}
+	if actorID == "" {
+		actorID = subjectID
+	}
+	client := ClientInfoFromContext(ctx)
+
+	event := &repository.AuditEvent{
+		EventType: eventType,
+		Outcome:   outcome,
+		ActorID:   actorID,
+		SubjectID: subjectID,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		RequestID: client.RequestID,
+		Details:   details,
+	}
+
+	// Record the event even when the caller has already gone away
+	if err := s.auditRepo.Create(context.WithoutCancel(ctx), event); err != nil {
+		s.logger.Error("Failed to record audit event", "error", err, "event_type", eventType, "outcome", outcome,
+			"subject_id", subjectID, "request_id", client.RequestID)
+	}
+}
+
+// Login methods recorded with login events
+const (
+	loginMethodPassword = "password"
+	loginMethodMFA      = "mfa"
+	loginMethodPasskey  = "passkey"
+)
+
+// loginDetails describes a login attempt; reason explains a failure
+func loginDetails(method, reason string) map[string]string {
+	details := map[string]string{"method": method}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	return details
+}
+
+// refreshDetails describes a refresh token exchange; reason explains a failure
+func refreshDetails(claims *JWTClaims, reason string) map[string]string {
+	details := map[string]string{"session_id": claims.SessionID}
+	if claims.ClientID != "" {
+		details["client_id"] = claims.ClientID
+	}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	return details
+}
@@ -6,13 +6,17 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/VariableSan/go-factory-microservice/pkg/common/config"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/database"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/logger"
 	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/keys"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/mail"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/password"
 	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/webauthn"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
 var (
@@ -20,49 +24,183 @@ var (
 	ErrUserExists         = errors.New("user already exists")
 	ErrUserNotFound       = errors.New("user not found")
 	ErrInvalidToken       = errors.New("invalid token")
+	ErrTokenReused        = errors.New("refresh token has already been used")
+)
+
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
 )
 
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Active    bool      `json:"active"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Roles         []string  `json:"roles"`
+	Permissions   []string  `json:"permissions"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Active        bool      `json:"active"`
+	EmailVerified bool      `json:"email_verified"`
+	// OrganizationID and OrganizationRole are the active organization of the token the user
+	// was validated from, if any
+	OrganizationID   string `json:"organization_id,omitempty"`
+	OrganizationRole string `json:"organization_role,omitempty"`
+	// ActorID is the administrator impersonating the user with the token, if any
+	ActorID string `json:"actor_id,omitempty"`
 }
 
 type AuthService struct {
-	userRepo      *repository.UserRepository
-	jwtSecret     string
-	redisClient   *redis.Client
-	logger        *logger.Logger
-	tokenExpiry   time.Duration
-	refreshExpiry time.Duration
+	userRepo            *repository.UserRepository
+	sessionRepo         *repository.SessionRepository
+	roleRepo            *repository.RoleRepository
+	clientRepo          *repository.ClientRepository
+	accountRepo         *repository.ServiceAccountRepository
+	resetRepo           *repository.PasswordResetRepository
+	verificationRepo    *repository.EmailVerificationRepository
+	emailChangeRepo     *repository.EmailChangeRepository
+	mfaRepo             *repository.MFARepository
+	webauthnRepo        *repository.WebAuthnRepository
+	auditRepo           *repository.AuditRepository
+	apiKeyRepo          *repository.APIKeyRepository
+	orgRepo             *repository.OrganizationRepository
+	keys                *keys.KeySet
+	redisClient         *redis.Client
+	mailer              mail.Sender
+	passwordHasher      password.Hasher
+	passwordPolicy      *password.Policy
+	logger              *logger.Logger
+	tokenExpiry         time.Duration
+	refreshExpiry       time.Duration
+	impersonationExpiry time.Duration
+	resetExpiry         time.Duration
+	resetURL            string
+	issuer              string
+	totpIssuer          string
+	relyingParty        *webauthn.RelyingParty
+
+	// Email verification policy
+	verifyURL            string
+	verifyExpiry         time.Duration
+	verifyResendInterval time.Duration
+	allowUnverifiedLogin bool
+	emailChangeURL       string
+
+	// Organization invitations
+	invitationURL    string
+	invitationExpiry time.Duration
+
+	// Account deletion and the other services' user data
+	deletionGracePeriod time.Duration
+	dataProviders       []AccountDataProvider
+
+	lockout lockoutPolicy
 }
 
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID      string   `json:"user_id"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	TokenType   string   `json:"token_type,omitempty"`
+	SessionID   string   `json:"sid,omitempty"`
+	Scope       string   `json:"scope,omitempty"`
+	ClientID    string   `json:"client_id,omitempty"`
+	// OrgID is the active organization and OrgRole the user's role in it
+	OrgID   string `json:"org_id,omitempty"`
+	OrgRole string `json:"org_role,omitempty"`
+	// Actor is the administrator impersonating the user (RFC 8693 act claim)
+	Actor *ActorClaim `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewAuthService(db *database.DB, jwtSecret string, redisClient *redis.Client, tokenExpiry, refreshExpiry time.Duration, logger *logger.Logger) *AuthService {
+// tokenGrant describes what an issued token is bound to
+type tokenGrant struct {
+	SessionID string
+	Scope     string // empty for first-party logins
+	ClientID  string // OAuth2 client the token was issued to, if any
+	OrgID     string // active organization, if any
+	OrgRole   string
+	ActorID   string // administrator impersonating the user, if any
+}
+
+func NewAuthService(db *database.DB, keySet *keys.KeySet, redisClient *redis.Client, mailer mail.Sender, passwordHasher password.Hasher, passwordPolicy *password.Policy, cfg *config.AuthConfig, logger *logger.Logger) *AuthService {
 	userRepo := repository.NewUserRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	roleRepo := repository.NewRoleRepository(db)
+	clientRepo := repository.NewClientRepository(db)
+	accountRepo := repository.NewServiceAccountRepository(db)
+	resetRepo := repository.NewPasswordResetRepository(db)
+	verificationRepo := repository.NewEmailVerificationRepository(db)
+	emailChangeRepo := repository.NewEmailChangeRepository(db)
+	mfaRepo := repository.NewMFARepository(db)
+	webauthnRepo := repository.NewWebAuthnRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	orgRepo := repository.NewOrganizationRepository(db)
 
 	service := &AuthService{
-		userRepo:      userRepo,
-		jwtSecret:     jwtSecret,
-		redisClient:   redisClient,
-		logger:        logger.WithComponent("auth-service"),
-		tokenExpiry:   tokenExpiry,
-		refreshExpiry: refreshExpiry,
+		userRepo:            userRepo,
+		sessionRepo:         sessionRepo,
+		roleRepo:            roleRepo,
+		clientRepo:          clientRepo,
+		accountRepo:         accountRepo,
+		resetRepo:           resetRepo,
+		verificationRepo:    verificationRepo,
+		emailChangeRepo:     emailChangeRepo,
+		mfaRepo:             mfaRepo,
+		webauthnRepo:        webauthnRepo,
+		auditRepo:           auditRepo,
+		apiKeyRepo:          apiKeyRepo,
+		orgRepo:             orgRepo,
+		keys:                keySet,
+		redisClient:         redisClient,
+		mailer:              mailer,
+		passwordHasher:      passwordHasher,
+		passwordPolicy:      passwordPolicy,
+		logger:              logger.WithComponent("auth-service"),
+		tokenExpiry:         cfg.TokenExpiry,
+		refreshExpiry:       cfg.RefreshExpiry,
+		impersonationExpiry: cfg.ImpersonationTokenExpiry,
+		resetExpiry:         cfg.PasswordResetExpiry,
+		resetURL:            cfg.PasswordResetURL,
+		issuer:              cfg.OIDCIssuer,
+		totpIssuer:          cfg.TOTPIssuer,
+		relyingParty: &webauthn.RelyingParty{
+			ID:      cfg.WebAuthnRPID,
+			Name:    cfg.WebAuthnRPName,
+			Origins: cfg.WebAuthnOrigins,
+		},
+
+		verifyURL:            cfg.EmailVerificationURL,
+		verifyExpiry:         cfg.EmailVerificationExpiry,
+		verifyResendInterval: cfg.EmailVerificationResendInterval,
+		allowUnverifiedLogin: cfg.AllowUnverifiedLogin,
+		emailChangeURL:       cfg.EmailChangeURL,
+
+		invitationURL:    cfg.OrganizationInvitationURL,
+		invitationExpiry: cfg.OrganizationInvitationExpiry,
+
+		deletionGracePeriod: cfg.AccountDeletionGracePeriod,
+
+		lockout: lockoutPolicy{
+			MaxAttempts:      cfg.LoginMaxAttempts,
+			MaxAttemptsPerIP: cfg.LoginMaxAttemptsPerIP,
+			Window:           cfg.LoginAttemptWindow,
+			LockoutDuration:  cfg.LoginLockoutDuration,
+			FailureDelay:     cfg.LoginFailureDelay,
+		},
 	}
 
 	return service
 }
 
 func (s *AuthService) Register(ctx context.Context, email, password, firstName, lastName string) (*User, error) {
+	if err := s.passwordPolicy.Check(password, email, firstName, lastName); err != nil {
+		return nil, err
+	}
+
 	// Check if user already exists
 	exists, err := s.userRepo.EmailExists(ctx, email)
 	if err != nil {
@@ -73,83 +211,204 @@ func (s *AuthService) Register(ctx context.Context, email, password, firstName,
 	}
 
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := s.passwordHasher.Hash(password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, err
 	}
 
 	// Create user
 	repoUser := &repository.User{
 		ID:        uuid.New().String(),
 		Email:     email,
-		Password:  string(hashedPassword),
+		Password:  hashedPassword,
 		FirstName: firstName,
 		LastName:  lastName,
 		Active:    true,
 	}
 
-	// Store user in database
-	if err := s.userRepo.Create(ctx, repoUser); err != nil {
+	// Store user in database; every new user starts with the regular user role
+	if err := s.userRepo.CreateWithRole(ctx, repoUser, RoleUser); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	s.audit(ctx, AuditRegister, AuditSuccess, repoUser.ID, nil)
+
+	// The account exists either way; the user can ask for another email if this one fails
+	if err := s.sendVerificationEmail(ctx, repoUser); err != nil {
+		s.logger.Error("Failed to send verification email", "error", err, "user_id", repoUser.ID)
+	}
 
 	// Convert to service user (without password)
 	user := &User{
-		ID:        repoUser.ID,
-		Email:     repoUser.Email,
-		FirstName: repoUser.FirstName,
-		LastName:  repoUser.LastName,
-		CreatedAt: repoUser.CreatedAt,
-		UpdatedAt: repoUser.UpdatedAt,
-		Active:    repoUser.Active,
+		ID:            repoUser.ID,
+		Email:         repoUser.Email,
+		FirstName:     repoUser.FirstName,
+		LastName:      repoUser.LastName,
+		Roles:         []string{RoleUser},
+		CreatedAt:     repoUser.CreatedAt,
+		UpdatedAt:     repoUser.UpdatedAt,
+		Active:        repoUser.Active,
+		EmailVerified: repoUser.EmailVerified,
 	}
 
 	return user, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, email, password string) (*User, string, string, error) {
+// LoginResult is the outcome of a successful password check. Users with two-factor authentication
+// only get an MFAToken, to be exchanged for access and refresh tokens with VerifyMFA.
+type LoginResult struct {
+	User         *User
+	AccessToken  string
+	RefreshToken string
+	MFAToken     string
+}
+
+// MFARequired reports whether the login must be completed with a second factor
+func (r *LoginResult) MFARequired() bool {
+	return r.MFAToken != ""
+}
+
+func (s *AuthService) Login(ctx context.Context, email, password string) (*LoginResult, error) {
+	user, err := s.authenticate(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.mfaEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if mfa {
+		mfaToken, err := s.generateMFAToken(user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate MFA token: %w", err)
+		}
+		return &LoginResult{User: user, MFAToken: mfaToken}, nil
+	}
+
+	accessToken, refreshToken, _, err := s.issueTokens(ctx, user, tokenGrant{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// authenticate checks a user's credentials and returns the user with roles loaded
+func (s *AuthService) authenticate(ctx context.Context, email, password string) (*User, error) {
+	lockoutEmail := normalizeLoginEmail(email)
+	ip := ClientInfoFromContext(ctx).IPAddress
+	if err := s.checkLoginLockout(ctx, lockoutEmail, ip); err != nil {
+		s.audit(ctx, AuditLogin, AuditFailure, "", loginDetails(loginMethodPassword, "locked"))
+		return nil, err
+	}
+
 	// Get user from database
 	repoUser, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		return nil, "", "", ErrInvalidCredentials
+		s.recordLoginFailure(ctx, lockoutEmail, ip)
+		s.audit(ctx, AuditLogin, AuditFailure, "", loginDetails(loginMethodPassword, "unknown_user"))
+		return nil, ErrInvalidCredentials
 	}
 
 	// Check password
-	if err := bcrypt.CompareHashAndPassword([]byte(repoUser.Password), []byte(password)); err != nil {
-		return nil, "", "", ErrInvalidCredentials
+	if !s.verifyPassword(repoUser, password) {
+		s.recordLoginFailure(ctx, lockoutEmail, ip)
+		s.audit(ctx, AuditLogin, AuditFailure, repoUser.ID, loginDetails(loginMethodPassword, "bad_password"))
+		return nil, ErrInvalidCredentials
+	}
+	s.clearLoginFailures(ctx, lockoutEmail)
+	s.upgradePasswordHash(ctx, repoUser, password)
+
+	// Only report the missing verification once the password is known to be right
+	if !repoUser.EmailVerified && !s.allowUnverifiedLogin {
+		s.audit(ctx, AuditLogin, AuditFailure, repoUser.ID, loginDetails(loginMethodPassword, "email_not_verified"))
+		return nil, ErrEmailNotVerified
 	}
 
 	// Convert to service user
 	user := &User{
-		ID:        repoUser.ID,
-		Email:     repoUser.Email,
-		FirstName: repoUser.FirstName,
-		LastName:  repoUser.LastName,
-		CreatedAt: repoUser.CreatedAt,
-		UpdatedAt: repoUser.UpdatedAt,
-		Active:    repoUser.Active,
+		ID:            repoUser.ID,
+		Email:         repoUser.Email,
+		FirstName:     repoUser.FirstName,
+		LastName:      repoUser.LastName,
+		CreatedAt:     repoUser.CreatedAt,
+		UpdatedAt:     repoUser.UpdatedAt,
+		Active:        repoUser.Active,
+		EmailVerified: repoUser.EmailVerified,
 	}
 
-	// Generate tokens
-	accessToken, err := s.generateAccessToken(user)
+	if err := s.loadAuthorization(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to load user roles: %w", err)
+	}
+	s.audit(ctx, AuditLogin, AuditSuccess, user.ID, loginDetails(loginMethodPassword, ""))
+
+	return user, nil
+}
+
+// verifyPassword reports whether password is the user's password. A hash that cannot be read
+// counts as a mismatch.
+func (s *AuthService) verifyPassword(repoUser *repository.User, password string) bool {
+	ok, err := s.passwordHasher.Verify(password, repoUser.Password)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("failed to generate access token: %w", err)
+		s.logger.Error("Failed to verify password hash", "error", err, "user_id", repoUser.ID)
+		return false
+	}
+	return ok
+}
+
+// upgradePasswordHash rehashes a verified password with the current algorithm and parameters if
+// its stored hash was made with others, so they can be raised without forcing password resets
+func (s *AuthService) upgradePasswordHash(ctx context.Context, repoUser *repository.User, password string) {
+	if !s.passwordHasher.NeedsRehash(repoUser.Password) {
+		return
 	}
 
-	refreshToken, err := s.generateRefreshToken(user)
+	hash, err := s.passwordHasher.Hash(password)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+		s.logger.Error("Failed to rehash password", "error", err, "user_id", repoUser.ID)
+		return
+	}
+	if err := s.userRepo.UpgradePasswordHash(ctx, repoUser.ID, repoUser.Password, hash); err != nil {
+		s.logger.Error("Failed to upgrade password hash", "error", err, "user_id", repoUser.ID)
+		return
 	}
 
-	// Store refresh token in Redis
-	if s.redisClient != nil {
-		refreshKey := fmt.Sprintf("refresh_token:%s", user.ID)
-		if err := s.redisClient.SetWithExpiry(ctx, refreshKey, refreshToken, s.refreshExpiry); err != nil {
-			s.logger.Warn("Failed to store refresh token in Redis", "error", err)
+	repoUser.Password = hash
+	s.logger.Info("Upgraded password hash", "user_id", repoUser.ID)
+}
+
+// issueTokens starts a new session for an authenticated user and returns its access token,
+// refresh token and session ID
+func (s *AuthService) issueTokens(ctx context.Context, user *User, grant tokenGrant) (string, string, string, error) {
+	// Every login starts a new session on the calling device
+	grant.SessionID = uuid.New().String()
+
+	// Third-party clients act for the user, not for any of their organizations
+	if grant.ClientID == "" {
+		orgID, orgRole, err := s.organizationGrant(ctx, user.ID, grant.OrgID)
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to load organization: %w", err)
 		}
+		grant.OrgID, grant.OrgRole = orgID, orgRole
+	}
+
+	// Generate tokens
+	accessToken, err := s.generateAccessToken(user, grant)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, tokenID, err := s.generateRefreshToken(user, grant)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	return user, accessToken, refreshToken, nil
+	// Remember the only refresh token of the session that may still be used
+	if err := s.createSession(ctx, grant.SessionID, user.ID, tokenID); err != nil {
+		return "", "", "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return accessToken, refreshToken, grant.SessionID, nil
 }
 
 func (s *AuthService) GetProfile(ctx context.Context, userID string) (*User, error) {
@@ -159,62 +418,152 @@ func (s *AuthService) GetProfile(ctx context.Context, userID string) (*User, err
 	}
 
 	user := &User{
-		ID:        repoUser.ID,
-		Email:     repoUser.Email,
-		FirstName: repoUser.FirstName,
-		LastName:  repoUser.LastName,
-		CreatedAt: repoUser.CreatedAt,
-		UpdatedAt: repoUser.UpdatedAt,
-		Active:    repoUser.Active,
+		ID:            repoUser.ID,
+		Email:         repoUser.Email,
+		FirstName:     repoUser.FirstName,
+		LastName:      repoUser.LastName,
+		CreatedAt:     repoUser.CreatedAt,
+		UpdatedAt:     repoUser.UpdatedAt,
+		Active:        repoUser.Active,
+		EmailVerified: repoUser.EmailVerified,
+	}
+
+	if err := s.loadAuthorization(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to load user roles: %w", err)
 	}
 
 	return user, nil
 }
 
 func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*User, error) {
-	claims, err := s.parseToken(tokenString)
-	if err != nil {
+	if IsAPIKey(tokenString) {
+		principal, err := s.AuthenticateAPIKey(ctx, tokenString)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+		return principal.User, nil
+	}
+
+	claims, err := s.verifyToken(ctx, tokenString)
+	if err != nil || claims.TokenType != tokenTypeAccess {
 		return nil, ErrInvalidToken
 	}
 
-	// Get user from database to ensure they still exist and are active
+	user, err := s.tokenUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens issued to OAuth2 clients carry no roles or permissions, so validating them must not
+	// hand out the user's own either
+	if claims.ClientID != "" {
+		user.Roles = []string{}
+		user.Permissions = []string{}
+	}
+	if claims.Actor != nil {
+		user.ActorID = claims.Actor.Subject
+	}
+
+	return user, nil
+}
+
+// tokenUser loads the user a verified token was issued to, checking the token is still good for
+// them: the account must exist and be active, and the user must still be a member of the
+// token's organization
+func (s *AuthService) tokenUser(ctx context.Context, claims *JWTClaims) (*User, error) {
 	user, err := s.GetProfile(ctx, claims.UserID)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
 
+	if claims.OrgID != "" {
+		member, err := s.orgRepo.GetMember(ctx, claims.OrgID, user.ID)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+		user.OrganizationID = member.OrganizationID
+		user.OrganizationRole = member.Role
+	}
+
 	return user, nil
 }
 
-func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
+// RefreshToken exchanges a first-party refresh token for a new access token and a new refresh token.
+// The presented refresh token is invalidated; presenting it again revokes its whole session.
+// Refresh tokens issued to OAuth2 clients must be exchanged through ExchangeRefreshToken instead.
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+	return s.rotateRefreshToken(ctx, refreshToken, "")
+}
+
+// rotateRefreshToken implements RefreshToken for tokens issued to clientID (empty for first-party tokens)
+func (s *AuthService) rotateRefreshToken(ctx context.Context, refreshToken, clientID string) (string, string, error) {
 	// Parse refresh token
-	claims, err := s.parseToken(refreshToken)
-	if err != nil {
-		return "", ErrInvalidToken
+	claims, err := s.verifyToken(ctx, refreshToken)
+	if err != nil || claims.TokenType != tokenTypeRefresh || claims.SessionID == "" || claims.ID == "" {
+		return "", "", ErrInvalidToken
+	}
+	if claims.ClientID != clientID {
+		s.audit(ctx, AuditTokenRefresh, AuditFailure, claims.UserID, refreshDetails(claims, "client_mismatch"))
+		return "", "", ErrInvalidToken
 	}
 
-	// Check if refresh token exists in Redis
-	if s.redisClient != nil {
-		refreshKey := fmt.Sprintf("refresh_token:%s", claims.UserID)
-		storedToken, err := s.redisClient.GetString(ctx, refreshKey)
-		if err != nil || storedToken != refreshToken {
-			return "", ErrInvalidToken
-		}
+	session, err := s.getSession(ctx, claims.SessionID)
+	if err != nil || !session.Active() || session.UserID != claims.UserID {
+		s.audit(ctx, AuditTokenRefresh, AuditFailure, claims.UserID, refreshDetails(claims, "session_revoked"))
+		return "", "", ErrInvalidToken
+	}
+
+	if session.RefreshTokenID != claims.ID {
+		s.revokeReusedSession(ctx, claims)
+		s.audit(ctx, AuditTokenRefresh, AuditFailure, claims.UserID, refreshDetails(claims, "token_reused"))
+		return "", "", ErrTokenReused
 	}
 
 	// Get user from database
 	user, err := s.GetProfile(ctx, claims.UserID)
 	if err != nil {
-		return "", ErrUserNotFound
+		return "", "", ErrUserNotFound
+	}
+
+	grant := tokenGrant{SessionID: session.ID, Scope: claims.Scope, ClientID: claims.ClientID}
+	if claims.OrgID != "" {
+		// Members who left or were removed lose the organization, and role changes take effect
+		if grant.OrgID, grant.OrgRole, err = s.organizationGrant(ctx, user.ID, claims.OrgID); err != nil {
+			return "", "", fmt.Errorf("failed to load organization: %w", err)
+		}
+	}
+	newRefreshToken, newTokenID, err := s.generateRefreshToken(user, grant)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate new refresh token: %w", err)
+	}
+
+	// Rotate the refresh token, detecting concurrent reuse of the same token
+	session.RefreshTokenID = newTokenID
+	session.ExpiresAt = time.Now().Add(s.refreshExpiry)
+	if client := ClientInfoFromContext(ctx); client.IPAddress != "" {
+		session.IPAddress = client.IPAddress
+		session.UserAgent = client.UserAgent
+	}
+
+	rotated, err := s.sessionRepo.RotateRefreshToken(ctx, session, claims.ID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		s.revokeReusedSession(ctx, claims)
+		s.audit(ctx, AuditTokenRefresh, AuditFailure, claims.UserID, refreshDetails(claims, "token_reused"))
+		return "", "", ErrTokenReused
 	}
+	s.cacheSession(ctx, session)
 
 	// Generate new access token
-	newAccessToken, err := s.generateAccessToken(user)
+	newAccessToken, err := s.generateAccessToken(user, grant)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate new access token: %w", err)
+		return "", "", fmt.Errorf("failed to generate new access token: %w", err)
 	}
+	s.audit(ctx, AuditTokenRefresh, AuditSuccess, user.ID, refreshDetails(claims, ""))
 
-	return newAccessToken, nil
+	return newAccessToken, newRefreshToken, nil
 }
 
 func (s *AuthService) Health() error {
@@ -235,43 +584,84 @@ func (s *AuthService) Health() error {
 	return nil
 }
 
-func (s *AuthService) generateAccessToken(user *User) (string, error) {
+// generateAccessToken issues an access token for user. Tokens issued to third-party OAuth2 clients
+// carry no roles or permissions, so signing in to a client never delegates the user's privileges.
+// Impersonation tokens name the administrator in the act claim and expire sooner.
+func (s *AuthService) generateAccessToken(user *User, grant tokenGrant) (string, error) {
+	expiry := s.tokenExpiry
+	if grant.ActorID != "" {
+		expiry = s.impersonationExpiry
+	}
+
 	claims := &JWTClaims{
-		UserID: user.ID,
-		Email:  user.Email,
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenType: tokenTypeAccess,
+		SessionID: grant.SessionID,
+		Scope:     grant.Scope,
+		ClientID:  grant.ClientID,
+		OrgID:     grant.OrgID,
+		OrgRole:   grant.OrgRole,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.tokenExpiry)),
+			Issuer:    s.issuer,
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Subject:   user.ID,
 		},
 	}
+	if grant.ClientID == "" {
+		claims.Roles = user.Roles
+		claims.Permissions = user.Permissions
+	}
+	if grant.ActorID != "" {
+		claims.Actor = &ActorClaim{Subject: grant.ActorID}
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return s.keys.Sign(claims)
 }
 
-func (s *AuthService) generateRefreshToken(user *User) (string, error) {
+// generateRefreshToken issues a refresh token belonging to the grant's session and returns it with its token ID
+func (s *AuthService) generateRefreshToken(user *User, grant tokenGrant) (string, string, error) {
+	tokenID := uuid.New().String()
 	claims := &JWTClaims{
-		UserID: user.ID,
-		Email:  user.Email,
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenType: tokenTypeRefresh,
+		SessionID: grant.SessionID,
+		Scope:     grant.Scope,
+		ClientID:  grant.ClientID,
+		OrgID:     grant.OrgID,
+		OrgRole:   grant.OrgRole,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.refreshExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Subject:   user.ID,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	signed, err := s.keys.Sign(claims)
+	if err != nil {
+		return "", "", err
+	}
+
+	return signed, tokenID, nil
+}
+
+// revokeReusedSession revokes the session of a refresh token that was presented after being rotated
+func (s *AuthService) revokeReusedSession(ctx context.Context, claims *JWTClaims) {
+	s.logger.Warn("Refresh token reuse detected, revoking session",
+		"user_id", claims.UserID, "session_id", claims.SessionID)
+
+	if err := s.RevokeSession(ctx, claims.UserID, claims.SessionID); err != nil && err != ErrSessionNotFound {
+		s.logger.Error("Failed to revoke session", "error", err)
+	}
 }
 
 func (s *AuthService) parseToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, s.keys.Keyfunc)
 
 	if err != nil {
 		return nil, err
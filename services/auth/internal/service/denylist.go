@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
+)
+
+// ErrRevocationUnavailable is returned by Logout when the session was revoked but the access token
+// could not be denylisted because Redis is not configured; the token stays valid until it expires
+var ErrRevocationUnavailable = errors.New("session revoked, but the access token stays valid until it expires because token revocation is unavailable")
+
+// Logout revokes the session of the given access token and denylists the token itself
+func (s *AuthService) Logout(ctx context.Context, accessToken string) error {
+	claims, err := s.verifyToken(ctx, accessToken)
+	if err != nil || claims.TokenType != tokenTypeAccess {
+		return ErrInvalidToken
+	}
+
+	if claims.SessionID != "" {
+		if err := s.RevokeSession(ctx, claims.UserID, claims.SessionID); err != nil && err != ErrSessionNotFound {
+			return err
+		}
+	}
+
+	s.audit(ctx, AuditLogout, AuditSuccess, claims.UserID, map[string]string{"session_id": claims.SessionID})
+
+	if s.redisClient == nil {
+		return ErrRevocationUnavailable
+	}
+
+	if err := s.denyToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked reports whether the token with tokenID, or the session it belongs to, has been revoked
+func (s *AuthService) IsTokenRevoked(ctx context.Context, tokenID, sessionID string) (bool, error) {
+	if s.redisClient == nil {
+		return false, nil
+	}
+
+	keys := make([]string, 0, 2)
+	if tokenID != "" {
+		keys = append(keys, deniedTokenKey(tokenID))
+	}
+	if sessionID != "" {
+		keys = append(keys, deniedSessionKey(sessionID))
+	}
+	if len(keys) == 0 {
+		return false, nil
+	}
+
+	count, err := s.redisClient.Exists(ctx, keys...)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token denylist: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// IsImpersonationRevoked reports whether an impersonation token issued at issuedAt has been
+// revoked because every session of the impersonated user or of the administrator was revoked
+// after it was issued, as ForceLogout and DeactivateUser do
+func (s *AuthService) IsImpersonationRevoked(ctx context.Context, actorID, userID string, issuedAt time.Time) (bool, error) {
+	if s.redisClient == nil {
+		return false, nil
+	}
+
+	for _, id := range []string{actorID, userID} {
+		if id == "" {
+			continue
+		}
+
+		value, err := s.redisClient.GetString(ctx, deniedImpersonationKey(id))
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to check impersonation denylist: %w", err)
+		}
+
+		revokedAt, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid impersonation denylist entry for user %s: %w", id, err)
+		}
+		// Token times have second precision, so a token issued in the second of the revocation is revoked too
+		if issuedAt.Unix() <= revokedAt {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// verifyToken parses a token and rejects it if it has been revoked
+func (s *AuthService) verifyToken(ctx context.Context, tokenString string) (*JWTClaims, error) {
+	claims, err := s.parseToken(tokenString)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	revoked, err := s.IsTokenRevoked(ctx, claims.ID, claims.SessionID)
+	if err != nil {
+		s.logger.Error("Failed to check token revocation", "error", err)
+		return nil, ErrInvalidToken
+	}
+	if revoked {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Actor != nil {
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+		revoked, err := s.IsImpersonationRevoked(ctx, claims.Actor.Subject, claims.UserID, issuedAt)
+		if err != nil {
+			s.logger.Error("Failed to check impersonation revocation", "error", err)
+			return nil, ErrInvalidToken
+		}
+		if revoked {
+			return nil, ErrInvalidToken
+		}
+	}
+
+	return claims, nil
+}
+
+// denyToken denylists a single token until its own expiry
+func (s *AuthService) denyToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if s.redisClient == nil || tokenID == "" {
+		return nil
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.redisClient.SetWithExpiry(ctx, deniedTokenKey(tokenID), 1, ttl)
+}
+
+// denySession denylists every access token of a session for as long as any of them can still be valid
+func (s *AuthService) denySession(ctx context.Context, sessionID string) {
+	ttl := s.maxAccessTokenExpiry()
+	if s.redisClient == nil || ttl <= 0 {
+		return
+	}
+
+	if err := s.redisClient.SetWithExpiry(ctx, deniedSessionKey(sessionID), 1, ttl); err != nil {
+		s.logger.Warn("Failed to denylist session tokens", "error", err, "session_id", sessionID)
+	}
+}
+
+// denyImpersonations revokes every impersonation token issued so far in which userID is either
+// the impersonated user or the administrator. Impersonation tokens have no session, so revoking
+// the sessions of either user would not reach them otherwise.
+func (s *AuthService) denyImpersonations(ctx context.Context, userID string) {
+	if s.redisClient == nil || s.impersonationExpiry <= 0 {
+		return
+	}
+
+	revokedAt := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := s.redisClient.SetWithExpiry(ctx, deniedImpersonationKey(userID), revokedAt, s.impersonationExpiry); err != nil {
+		s.logger.Warn("Failed to denylist impersonation tokens", "error", err, "user_id", userID)
+	}
+}
+
+// maxAccessTokenExpiry is the longest an access token of any kind stays valid
+func (s *AuthService) maxAccessTokenExpiry() time.Duration {
+	if s.impersonationExpiry > s.tokenExpiry {
+		return s.impersonationExpiry
+	}
+	return s.tokenExpiry
+}
+
+func deniedTokenKey(tokenID string) string {
+	return fmt.Sprintf("denylist:token:%s", tokenID)
+}
+
+func deniedSessionKey(sessionID string) string {
+	return fmt.Sprintf("denylist:session:%s", sessionID)
+}
+
+func deniedImpersonationKey(userID string) string {
+	return fmt.Sprintf("denylist:impersonation:%s", userID)
+}
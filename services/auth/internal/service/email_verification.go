@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/mail"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+var (
+	ErrEmailNotVerified         = errors.New("email address has not been verified")
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+	ErrVerificationThrottled    = errors.New("a verification email was sent recently, please wait before requesting another")
+)
+
+// VerifyEmail marks the email of a user as verified using a token from a verification email
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrInvalidVerificationToken
+	}
+
+	userID, err := s.verificationRepo.Consume(ctx, hashOneTimeToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrVerificationTokenNotFound) {
+			return ErrInvalidVerificationToken
+		}
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	s.logger.Info("Email verified", "user_id", userID)
+	return nil
+}
+
+// ResendVerification sends a new verification email to an unverified user, at most once per
+// resend interval. Unknown and already verified addresses are silently ignored.
+func (s *AuthService) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil || user.EmailVerified {
+		return nil
+	}
+
+	lastSent, err := s.verificationRepo.LastSentAt(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if time.Since(lastSent) < s.verifyResendInterval {
+		return ErrVerificationThrottled
+	}
+
+	return s.sendVerificationEmail(ctx, user)
+}
+
+// sendVerificationEmail issues a new verification token for user and emails it
+func (s *AuthService) sendVerificationEmail(ctx context.Context, user *repository.User) error {
+	token, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		return err
+	}
+
+	err = s.verificationRepo.Create(ctx, &repository.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(s.verifyExpiry),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store verification token: %w", err)
+	}
+
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address using the link below. It expires in %s.\n\n%s\n\nIf you did not create an account, you can ignore this email.\n",
+			user.FirstName, s.verifyExpiry, linkWithToken(s.verifyURL, token)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
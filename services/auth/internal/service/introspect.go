@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// IntrospectionResponse is an RFC 7662 token introspection response. Only Active is set for
+// tokens that are invalid, expired or revoked.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+	Iss       string `json:"iss,omitempty"`
+	Jti       string `json:"jti,omitempty"`
+	Sid       string `json:"sid,omitempty"`
+	OrgID     string `json:"org_id,omitempty"`
+	OrgRole   string `json:"org_role,omitempty"`
+	// Act names the administrator impersonating the user (RFC 8693)
+	Act *ActorClaim `json:"act,omitempty"`
+}
+
+// Introspect reports whether a token is currently active and describes it (RFC 7662). The caller
+// must authenticate as a service account or a confidential OAuth2 client.
+func (s *AuthService) Introspect(ctx context.Context, clientID, clientSecret, token string) (*IntrospectionResponse, error) {
+	if err := s.authenticateIntrospectionClient(ctx, clientID, clientSecret); err != nil {
+		return nil, err
+	}
+
+	inactive := &IntrospectionResponse{Active: false}
+
+	claims, err := s.verifyToken(ctx, token)
+	if err != nil {
+		return inactive, nil
+	}
+
+	switch claims.TokenType {
+	case tokenTypeAccess:
+	case tokenTypeRefresh:
+		// A refresh token is only active while it is the current token of a live session
+		session, err := s.getSession(ctx, claims.SessionID)
+		if err != nil || !session.Active() || session.RefreshTokenID != claims.ID {
+			return inactive, nil
+		}
+	default:
+		// ID tokens are not bearer credentials
+		return inactive, nil
+	}
+
+	// Tokens of users stop being active once the account is deactivated or leaves the token's
+	// organization, as in ValidateToken; service account tokens have no user
+	if claims.UserID != "" {
+		if _, err := s.tokenUser(ctx, claims); err != nil {
+			return inactive, nil
+		}
+	}
+
+	resp := &IntrospectionResponse{
+		Active:   true,
+		Scope:    claims.Scope,
+		ClientID: claims.ClientID,
+		Username: claims.Email,
+		Sub:      claims.Subject,
+		Iss:      claims.Issuer,
+		Jti:      claims.ID,
+		Sid:      claims.SessionID,
+		OrgID:    claims.OrgID,
+		OrgRole:  claims.OrgRole,
+		Act:      claims.Actor,
+	}
+	if claims.TokenType == tokenTypeAccess {
+		resp.TokenType = "Bearer"
+	}
+	if claims.ExpiresAt != nil {
+		resp.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		resp.Iat = claims.IssuedAt.Unix()
+	}
+
+	return resp, nil
+}
+
+// authenticateIntrospectionClient accepts service accounts and confidential OAuth2 clients;
+// public clients cannot keep a secret and may not introspect tokens
+func (s *AuthService) authenticateIntrospectionClient(ctx context.Context, clientID, clientSecret string) error {
+	if clientID == "" || clientSecret == "" {
+		return newOAuthError("invalid_client", "client authentication required")
+	}
+
+	account, err := s.accountRepo.GetByClientID(ctx, clientID)
+	if err == nil {
+		if bcrypt.CompareHashAndPassword([]byte(account.SecretHash), []byte(clientSecret)) != nil {
+			return newOAuthError("invalid_client", "client authentication failed")
+		}
+		return nil
+	}
+	if !errors.Is(err, repository.ErrServiceAccountNotFound) {
+		return fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	client, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil || client.Public() {
+		return newOAuthError("invalid_client", "unknown client")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(clientSecret)) != nil {
+		return newOAuthError("invalid_client", "client authentication failed")
+	}
+
+	return nil
+}
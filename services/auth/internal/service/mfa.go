@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/totp"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMFAAlreadyEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrMFARequired        = errors.New("two-factor authentication code required")
+	ErrInvalidMFACode     = errors.New("invalid two-factor authentication code")
+	ErrTooManyMFAAttempts = errors.New("too many two-factor authentication attempts, please log in again")
+	ErrMFAUnavailable     = errors.New("two-factor authentication is temporarily unavailable, try again later")
+)
+
+const (
+	tokenTypeMFA = "mfa"
+
+	// mfaTokenExpiry is how long a user has to enter their code after the password was accepted
+	mfaTokenExpiry = 5 * time.Minute
+	// maxMFAAttempts limits code guesses per MFA token
+	maxMFAAttempts = 5
+
+	recoveryCodeCount = 10
+)
+
+// TOTPEnrollment is a new TOTP secret waiting to be confirmed with a code
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// EnrollTOTP generates a new TOTP secret for a user. It only takes effect once confirmed with
+// ConfirmTOTP; enrolling again before that replaces the secret.
+func (s *AuthService) EnrollTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error) {
+	if err := impersonationAllowed(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := s.mfaRepo.SaveTOTP(ctx, userID, secret)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	return &TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(s.totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP turns on two-factor authentication with a code from the enrolled secret and returns
+// one-time recovery codes. They are only shown this once.
+func (s *AuthService) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	secret, err := s.mfaRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTOTPNotFound) {
+			return nil, ErrMFANotEnabled
+		}
+		return nil, err
+	}
+	if secret.Confirmed() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(secret.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.ConfirmTOTP(ctx, userID, step, hashes); err != nil {
+		if errors.Is(err, repository.ErrTOTPNotFound) {
+			return nil, ErrMFAAlreadyEnabled
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Two-factor authentication enabled", "user_id", userID)
+	return codes, nil
+}
+
+// DisableTOTP turns off two-factor authentication after checking a current TOTP or recovery code
+func (s *AuthService) DisableTOTP(ctx context.Context, userID, code string) error {
+	if err := impersonationAllowed(ctx); err != nil {
+		return err
+	}
+
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Two-factor authentication disabled", "user_id", userID)
+	return nil
+}
+
+// VerifyMFA completes a login that returned an MFA token, issuing access and refresh tokens for a
+// valid TOTP or recovery code. Wrong codes count towards the account's failed login limit, so
+// logging in again for a fresh MFA token does not allow more guesses. As the password is already
+// known at this point, codes are not checked at all while the counters cannot be kept.
+func (s *AuthService) VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResult, error) {
+	claims, err := s.verifyToken(ctx, mfaToken)
+	if err != nil || claims.TokenType != tokenTypeMFA {
+		return nil, ErrInvalidToken
+	}
+
+	lockoutEmail := normalizeLoginEmail(claims.Email)
+	if err := s.checkMFALockout(ctx, lockoutEmail); err != nil {
+		if err == ErrAccountLocked {
+			s.audit(ctx, AuditLogin, AuditFailure, claims.UserID, loginDetails(loginMethodMFA, "locked"))
+		}
+		return nil, err
+	}
+
+	attempts, err := s.redisClient.IncrementWithExpiry(ctx, mfaAttemptsKey(claims.ID), mfaTokenExpiry)
+	if err != nil {
+		s.logger.Error("Failed to count MFA attempts", "error", err)
+		return nil, ErrMFAUnavailable
+	}
+	if attempts > maxMFAAttempts {
+		return nil, ErrTooManyMFAAttempts
+	}
+
+	if err := s.verifySecondFactor(ctx, claims.UserID, code); err != nil {
+		if err == ErrInvalidMFACode {
+			s.recordLoginFailure(ctx, lockoutEmail, ClientInfoFromContext(ctx).IPAddress)
+		}
+		s.audit(ctx, AuditLogin, AuditFailure, claims.UserID, loginDetails(loginMethodMFA, "invalid_code"))
+		return nil, err
+	}
+	s.clearLoginFailures(ctx, lockoutEmail)
+
+	// The MFA token is single-use where it can be denylisted
+	if err := s.denyToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		s.logger.Warn("Failed to denylist MFA token", "error", err)
+	}
+
+	user, err := s.GetProfile(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshToken, _, err := s.issueTokens(ctx, user, tokenGrant{})
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, AuditLogin, AuditSuccess, user.ID, loginDetails(loginMethodMFA, ""))
+
+	return &LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// checkMFALockout rejects second-factor attempts for a locked account. Unlike checkLoginLockout it
+// fails closed: the password is already known, so the counters are all that stop code guessing.
+func (s *AuthService) checkMFALockout(ctx context.Context, email string) error {
+	if s.redisClient == nil {
+		return ErrMFAUnavailable
+	}
+
+	locked, err := s.redisClient.Exists(ctx, accountLockoutKey(email))
+	if err != nil {
+		s.logger.Error("Failed to check account lockout", "error", err)
+		return ErrMFAUnavailable
+	}
+	if locked > 0 {
+		return ErrAccountLocked
+	}
+
+	return nil
+}
+
+// mfaEnabled reports whether a user has confirmed a TOTP secret
+func (s *AuthService) mfaEnabled(ctx context.Context, userID string) (bool, error) {
+	secret, err := s.mfaRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTOTPNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return secret.Confirmed(), nil
+}
+
+// verifySecondFactor accepts a TOTP code that was not used before, or an unused recovery code
+func (s *AuthService) verifySecondFactor(ctx context.Context, userID, code string) error {
+	secret, err := s.mfaRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTOTPNotFound) {
+			return ErrMFANotEnabled
+		}
+		return err
+	}
+	if !secret.Confirmed() {
+		return ErrMFANotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(secret.Secret, code, time.Now()); ok {
+		used, err := s.mfaRepo.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := s.mfaRepo.UseRecoveryCode(ctx, userID, hashOneTimeToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidMFACode
+	}
+
+	s.logger.Info("Recovery code used", "user_id", userID)
+	return nil
+}
+
+// generateMFAToken issues the short-lived token proving that a user's password was accepted
+func (s *AuthService) generateMFAToken(user *User) (string, error) {
+	claims := &JWTClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenType: tokenTypeMFA,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(mfaTokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   user.ID,
+		},
+	}
+
+	return s.keys.Sign(claims)
+}
+
+// generateRecoveryCodes returns recovery codes formatted as xxxx-xxxx and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		raw := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+		codes = append(codes, raw[:4]+"-"+raw[4:])
+		hashes = append(hashes, hashOneTimeToken(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode ignores case, dashes and spaces the user may have typed
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func mfaAttemptsKey(tokenID string) string {
+	return fmt.Sprintf("mfa_attempts:%s", tokenID)
+}
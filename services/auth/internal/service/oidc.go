@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Standard OpenID Connect scopes
+const (
+	ScopeOpenID        = "openid"
+	ScopeProfile       = "profile"
+	ScopeEmail         = "email"
+	ScopeOfflineAccess = "offline_access"
+)
+
+const authorizationCodeExpiry = time.Minute
+
+var (
+	ErrInvalidClient      = errors.New("invalid client")
+	ErrInvalidRedirectURI = errors.New("invalid redirect uri")
+)
+
+// OAuthError is an OAuth2 error response (RFC 6749 section 5.2)
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+func newOAuthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+// AuthorizationRequest holds the parameters of an authorization code request
+type AuthorizationRequest struct {
+	ClientID            string
+	RedirectURI         string
+	ResponseType        string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// TokenResponse is a successful OAuth2 token endpoint response
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// ProviderMetadata is the OpenID Connect discovery document
+type ProviderMetadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// authorizationCode is what an issued code stands for while it waits to be exchanged
+type authorizationCode struct {
+	ClientID      string `json:"client_id"`
+	RedirectURI   string `json:"redirect_uri"`
+	UserID        string `json:"user_id"`
+	Scope         string `json:"scope"`
+	Nonce         string `json:"nonce"`
+	CodeChallenge string `json:"code_challenge"`
+	AuthTime      int64  `json:"auth_time"`
+}
+
+// OIDCEnabled reports whether the service runs as an OpenID Connect provider
+func (s *AuthService) OIDCEnabled() bool {
+	return s.issuer != ""
+}
+
+// Discovery returns the OpenID Connect provider metadata
+func (s *AuthService) Discovery() *ProviderMetadata {
+	return &ProviderMetadata{
+		Issuer:                            s.issuer,
+		AuthorizationEndpoint:             s.issuer + "/oauth2/authorize",
+		TokenEndpoint:                     s.issuer + "/oauth2/token",
+		UserInfoEndpoint:                  s.issuer + "/userinfo",
+		IntrospectionEndpoint:             s.issuer + "/oauth2/introspect",
+		JWKSURI:                           s.issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   []string{ScopeOpenID, ScopeProfile, ScopeEmail, ScopeOfflineAccess},
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "refresh_token", "client_credentials"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{s.keys.Active().Algorithm()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "sid",
+			"name", "given_name", "family_name", "email", "email_verified", "updated_at",
+		},
+	}
+}
+
+// ValidateAuthorizationRequest checks an authorization request. ErrInvalidClient and ErrInvalidRedirectURI
+// must be shown to the user; an *OAuthError may be sent back to the client's redirect URI.
+func (s *AuthService) ValidateAuthorizationRequest(ctx context.Context, req *AuthorizationRequest) error {
+	client, err := s.clientRepo.GetByID(ctx, req.ClientID)
+	if err != nil {
+		return ErrInvalidClient
+	}
+
+	if !contains(client.RedirectURIs, req.RedirectURI) {
+		return ErrInvalidRedirectURI
+	}
+
+	if req.ResponseType != "code" {
+		return newOAuthError("unsupported_response_type", "only the code response type is supported")
+	}
+
+	scopes := strings.Fields(req.Scope)
+	if !contains(scopes, ScopeOpenID) {
+		return newOAuthError("invalid_scope", "the openid scope is required")
+	}
+	for _, scope := range scopes {
+		if !contains(client.Scopes, scope) {
+			return newOAuthError("invalid_scope", fmt.Sprintf("scope %q is not allowed for this client", scope))
+		}
+	}
+
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != "S256" {
+		return newOAuthError("invalid_request", "PKCE with code_challenge_method S256 is required")
+	}
+
+	return nil
+}
+
+// Authorize authenticates the user behind an authorization request, including their second factor
+// if enabled, and returns a single-use authorization code
+func (s *AuthService) Authorize(ctx context.Context, req *AuthorizationRequest, email, password, mfaCode string) (string, error) {
+	if err := s.ValidateAuthorizationRequest(ctx, req); err != nil {
+		return "", err
+	}
+
+	if s.redisClient == nil {
+		return "", newOAuthError("server_error", "authorization codes are unavailable")
+	}
+
+	user, err := s.authenticate(ctx, email, password)
+	if err != nil {
+		return "", err
+	}
+
+	mfa, err := s.mfaEnabled(ctx, user.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if mfa {
+		if mfaCode == "" {
+			return "", ErrMFARequired
+		}
+		if err := s.verifySecondFactor(ctx, user.ID, mfaCode); err != nil {
+			return "", err
+		}
+	}
+
+	data, err := json.Marshal(&authorizationCode{
+		ClientID:      req.ClientID,
+		RedirectURI:   req.RedirectURI,
+		UserID:        user.ID,
+		Scope:         req.Scope,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      time.Now().Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode authorization code: %w", err)
+	}
+
+	code := uuid.New().String()
+	if err := s.redisClient.SetWithExpiry(ctx, authorizationCodeKey(code), data, authorizationCodeExpiry); err != nil {
+		return "", fmt.Errorf("failed to store authorization code: %w", err)
+	}
+
+	return code, nil
+}
+
+// ExchangeAuthorizationCode redeems an authorization code for tokens after verifying the client and PKCE verifier
+func (s *AuthService) ExchangeAuthorizationCode(ctx context.Context, clientID, clientSecret, code, redirectURI, codeVerifier string) (*TokenResponse, error) {
+	if _, err := s.authenticateClient(ctx, clientID, clientSecret); err != nil {
+		return nil, err
+	}
+
+	if s.redisClient == nil {
+		return nil, newOAuthError("server_error", "authorization codes are unavailable")
+	}
+
+	// Codes are single-use: fetching one deletes it
+	data, err := s.redisClient.GetDelete(ctx, authorizationCodeKey(code))
+	if err != nil {
+		if err != redis.Nil {
+			s.logger.Error("Failed to read authorization code", "error", err)
+		}
+		return nil, newOAuthError("invalid_grant", "authorization code is invalid or expired")
+	}
+
+	authCode := &authorizationCode{}
+	if err := json.Unmarshal([]byte(data), authCode); err != nil {
+		return nil, newOAuthError("invalid_grant", "authorization code is invalid or expired")
+	}
+
+	if authCode.ClientID != clientID || authCode.RedirectURI != redirectURI {
+		return nil, newOAuthError("invalid_grant", "authorization code was issued to another client or redirect URI")
+	}
+
+	if !verifyCodeChallenge(codeVerifier, authCode.CodeChallenge) {
+		return nil, newOAuthError("invalid_grant", "code_verifier does not match code_challenge")
+	}
+
+	user, err := s.GetProfile(ctx, authCode.UserID)
+	if err != nil {
+		return nil, newOAuthError("invalid_grant", "user is no longer active")
+	}
+
+	grant := tokenGrant{Scope: authCode.Scope, ClientID: clientID}
+	accessToken, refreshToken, sessionID, err := s.issueTokens(ctx, user, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := s.generateIDToken(user, clientID, sessionID, authCode.Scope, authCode.Nonce, authCode.AuthTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate id token: %w", err)
+	}
+
+	resp := &TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.tokenExpiry.Seconds()),
+		IDToken:     idToken,
+		Scope:       authCode.Scope,
+	}
+	if hasScope(authCode.Scope, ScopeOfflineAccess) {
+		resp.RefreshToken = refreshToken
+	}
+
+	return resp, nil
+}
+
+// ExchangeRefreshToken rotates a refresh token issued to an OAuth2 client
+func (s *AuthService) ExchangeRefreshToken(ctx context.Context, clientID, clientSecret, refreshToken string) (*TokenResponse, error) {
+	if _, err := s.authenticateClient(ctx, clientID, clientSecret); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.parseToken(refreshToken)
+	if err != nil || claims.ClientID != clientID {
+		return nil, newOAuthError("invalid_grant", "refresh token is invalid or was issued to another client")
+	}
+
+	accessToken, newRefreshToken, err := s.rotateRefreshToken(ctx, refreshToken, clientID)
+	if err != nil {
+		if err == ErrInvalidToken || err == ErrTokenReused || err == ErrUserNotFound {
+			return nil, newOAuthError("invalid_grant", err.Error())
+		}
+		return nil, err
+	}
+
+	resp := &TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.tokenExpiry.Seconds()),
+		RefreshToken: newRefreshToken,
+		Scope:        claims.Scope,
+	}
+
+	if hasScope(claims.Scope, ScopeOpenID) {
+		user, err := s.GetProfile(ctx, claims.UserID)
+		if err != nil {
+			return nil, newOAuthError("invalid_grant", "user is no longer active")
+		}
+		resp.IDToken, err = s.generateIDToken(user, clientID, claims.SessionID, claims.Scope, "", 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate id token: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// UserInfo returns the OpenID Connect claims about the owner of an access token, limited by its scope
+func (s *AuthService) UserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+	claims, err := s.verifyToken(ctx, accessToken)
+	if err != nil || claims.TokenType != tokenTypeAccess {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.GetProfile(ctx, claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// First-party tokens carry no scope and may see the full profile
+	scope := claims.Scope
+	if scope == "" {
+		scope = strings.Join([]string{ScopeOpenID, ScopeProfile, ScopeEmail}, " ")
+	}
+
+	return userClaims(user, scope), nil
+}
+
+// authenticateClient verifies OAuth2 client credentials; public clients authenticate with their ID alone
+func (s *AuthService) authenticateClient(ctx context.Context, clientID, clientSecret string) (*repository.OAuthClient, error) {
+	client, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		return nil, newOAuthError("invalid_client", "unknown client")
+	}
+
+	if client.Public() {
+		return client, nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(clientSecret)); err != nil {
+		return nil, newOAuthError("invalid_client", "client authentication failed")
+	}
+
+	return client, nil
+}
+
+// generateIDToken issues an OpenID Connect ID token for clientID; authTime and nonce are omitted when zero
+func (s *AuthService) generateIDToken(user *User, clientID, sessionID, scope, nonce string, authTime int64) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": s.issuer,
+		"aud": clientID,
+		"exp": now.Add(s.tokenExpiry).Unix(),
+		"iat": now.Unix(),
+		"sid": sessionID,
+	}
+	for name, value := range userClaims(user, scope) {
+		claims[name] = value
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	if authTime != 0 {
+		claims["auth_time"] = authTime
+	}
+
+	return s.keys.Sign(claims)
+}
+
+// userClaims maps a user to standard OpenID Connect claims allowed by scope
+func userClaims(user *User, scope string) map[string]interface{} {
+	claims := map[string]interface{}{
+		"sub": user.ID,
+	}
+
+	if hasScope(scope, ScopeProfile) {
+		claims["name"] = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		claims["given_name"] = user.FirstName
+		claims["family_name"] = user.LastName
+		claims["updated_at"] = user.UpdatedAt.Unix()
+	}
+
+	if hasScope(scope, ScopeEmail) {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.EmailVerified
+	}
+
+	return claims
+}
+
+// verifyCodeChallenge checks a PKCE code verifier against an S256 code challenge (RFC 7636)
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:]) == challenge
+}
+
+func hasScope(scope, want string) bool {
+	return contains(strings.Fields(scope), want)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func authorizationCodeKey(code string) string {
+	return fmt.Sprintf("oauth_code:%s", code)
+}
@@ -0,0 +1,542 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/mail"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+var (
+	ErrOrganizationNotFound       = errors.New("organization not found")
+	ErrOrganizationSlugTaken      = errors.New("organization slug is already taken")
+	ErrInvalidOrganization        = errors.New("organization name is required and slugs must be 3-63 lowercase letters, digits or hyphens")
+	ErrInvalidOrganizationRole    = errors.New("organization role must be owner, admin or member")
+	ErrOrganizationForbidden      = errors.New("insufficient role in the organization")
+	ErrOrganizationMemberNotFound = errors.New("organization member not found")
+	ErrAlreadyOrganizationMember  = errors.New("user is already a member of the organization")
+	ErrLastOrganizationOwner      = errors.New("organization must keep at least one owner")
+	ErrInvalidInvitation          = errors.New("invalid or expired organization invitation")
+	ErrInvitationEmailMismatch    = errors.New("invitation was sent to a different email address")
+	ErrOrganizationClientToken    = errors.New("organizations cannot be switched with a token issued to an oauth2 client")
+)
+
+// Roles of a user within an organization. Owners can do everything, admins manage members and
+// invitations, members can only see the organization.
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+var organizationSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,61}[a-z0-9]$`)
+
+// Organization is a tenant users work in. Role is the role of the user it was loaded for.
+type Organization struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Role      string    `json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+type OrganizationInvitation struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role"`
+	InvitedBy      string    `json:"invited_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// CreateOrganization creates an organization with the caller as its owner
+func (s *AuthService) CreateOrganization(ctx context.Context, userID, name, slug string) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || !organizationSlugPattern.MatchString(slug) {
+		return nil, ErrInvalidOrganization
+	}
+
+	repoOrg := &repository.Organization{Name: name, Slug: slug}
+	if err := s.orgRepo.Create(ctx, repoOrg, userID); err != nil {
+		if errors.Is(err, repository.ErrOrganizationSlugTaken) {
+			return nil, ErrOrganizationSlugTaken
+		}
+		return nil, err
+	}
+	s.audit(ctx, AuditOrganizationCreate, AuditSuccess, userID, map[string]string{"organization_id": repoOrg.ID})
+
+	s.logger.Info("Organization created", "organization_id", repoOrg.ID, "user_id", userID)
+	return convertOrganization(repoOrg, OrgRoleOwner), nil
+}
+
+// ListOrganizations returns the organizations a user is a member of, with the user's role in each
+func (s *AuthService) ListOrganizations(ctx context.Context, userID string) ([]*Organization, error) {
+	memberships, err := s.orgRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]*Organization, 0, len(memberships))
+	for _, membership := range memberships {
+		orgs = append(orgs, convertOrganization(&membership.Organization, membership.Role))
+	}
+
+	return orgs, nil
+}
+
+// DeleteOrganization deletes an organization the caller owns, with all its memberships and
+// invitations
+func (s *AuthService) DeleteOrganization(ctx context.Context, userID, orgID string) error {
+	if _, err := s.requireOrganizationRole(ctx, userID, orgID, OrgRoleOwner); err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.Delete(ctx, orgID); err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return ErrOrganizationNotFound
+		}
+		return err
+	}
+	s.audit(ctx, AuditOrganizationDelete, AuditSuccess, userID, map[string]string{"organization_id": orgID})
+
+	s.logger.Info("Organization deleted", "organization_id", orgID, "user_id", userID)
+	return nil
+}
+
+// ListOrganizationMembers returns the members of an organization the caller belongs to
+func (s *AuthService) ListOrganizationMembers(ctx context.Context, userID, orgID string) ([]*OrganizationMember, error) {
+	if _, err := s.requireOrganizationRole(ctx, userID, orgID, OrgRoleMember); err != nil {
+		return nil, err
+	}
+
+	repoMembers, err := s.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*OrganizationMember, 0, len(repoMembers))
+	for _, repoMember := range repoMembers {
+		members = append(members, convertOrganizationMember(repoMember))
+	}
+
+	return members, nil
+}
+
+// UpdateOrganizationMember changes the role of a member. Admins manage admins and members; only
+// owners can make someone an owner or change another owner's role.
+func (s *AuthService) UpdateOrganizationMember(ctx context.Context, userID, orgID, memberID, role string) error {
+	if !validOrganizationRole(role) {
+		return ErrInvalidOrganizationRole
+	}
+
+	callerRole, err := s.requireOrganizationRole(ctx, userID, orgID, OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.getOrganizationMember(ctx, orgID, memberID)
+	if err != nil {
+		return err
+	}
+	if (role == OrgRoleOwner || member.Role == OrgRoleOwner) && callerRole != OrgRoleOwner {
+		return ErrOrganizationForbidden
+	}
+
+	if err := s.orgRepo.UpdateMemberRole(ctx, orgID, memberID, role); err != nil {
+		return mapOrganizationMemberError(err)
+	}
+	s.audit(ctx, AuditOrganizationMemberUpdate, AuditSuccess, memberID, map[string]string{"organization_id": orgID, "role": role})
+
+	return nil
+}
+
+// RemoveOrganizationMember removes a member from an organization. Members can always leave;
+// removing someone else takes an admin, and removing an owner takes an owner.
+func (s *AuthService) RemoveOrganizationMember(ctx context.Context, userID, orgID, memberID string) error {
+	if memberID != userID {
+		callerRole, err := s.requireOrganizationRole(ctx, userID, orgID, OrgRoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		member, err := s.getOrganizationMember(ctx, orgID, memberID)
+		if err != nil {
+			return err
+		}
+		if member.Role == OrgRoleOwner && callerRole != OrgRoleOwner {
+			return ErrOrganizationForbidden
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(ctx, orgID, memberID); err != nil {
+		if errors.Is(err, repository.ErrMemberNotFound) && memberID == userID {
+			return ErrOrganizationNotFound
+		}
+		return mapOrganizationMemberError(err)
+	}
+	s.audit(ctx, AuditOrganizationMemberRemove, AuditSuccess, memberID, map[string]string{"organization_id": orgID})
+
+	return nil
+}
+
+// InviteOrganizationMember emails an invitation to join an organization with role to email.
+// Admins can invite admins and members; only owners can invite owners.
+func (s *AuthService) InviteOrganizationMember(ctx context.Context, userID, orgID, email, role string) (*OrganizationInvitation, error) {
+	if !validOrganizationRole(role) {
+		return nil, ErrInvalidOrganizationRole
+	}
+
+	callerRole, err := s.requireOrganizationRole(ctx, userID, orgID, OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if role == OrgRoleOwner && callerRole != OrgRoleOwner {
+		return nil, ErrOrganizationForbidden
+	}
+
+	isMember, err := s.orgRepo.HasMemberWithEmail(ctx, orgID, email)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, ErrAlreadyOrganizationMember
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &repository.OrganizationInvitation{
+		OrganizationID: orgID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      tokenHash,
+		InvitedBy:      userID,
+		ExpiresAt:      time.Now().Add(s.invitationExpiry),
+	}
+	if err := s.orgRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      email,
+		Subject: fmt.Sprintf("You are invited to join %s", org.Name),
+		Body: fmt.Sprintf("Hi,\n\nYou have been invited to join %s as %s. Log in or sign up with this email address and accept the invitation using the link below. It expires in %s.\n\n%s\n\nIf you do not want to join, you can decline the invitation or ignore this email.\n",
+			org.Name, role, s.invitationExpiry, linkWithToken(s.invitationURL, token)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send organization invitation: %w", err)
+	}
+	s.audit(ctx, AuditOrganizationInvite, AuditSuccess, "", map[string]string{"organization_id": orgID, "invitation_id": invitation.ID, "step": "invite", "role": role})
+
+	return convertOrganizationInvitation(invitation), nil
+}
+
+// ListOrganizationInvitations returns the pending invitations of an organization
+func (s *AuthService) ListOrganizationInvitations(ctx context.Context, userID, orgID string) ([]*OrganizationInvitation, error) {
+	if _, err := s.requireOrganizationRole(ctx, userID, orgID, OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	repoInvitations, err := s.orgRepo.ListPendingInvitations(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*OrganizationInvitation, 0, len(repoInvitations))
+	for _, repoInvitation := range repoInvitations {
+		invitations = append(invitations, convertOrganizationInvitation(repoInvitation))
+	}
+
+	return invitations, nil
+}
+
+// RevokeOrganizationInvitation withdraws a pending invitation so it can no longer be accepted
+func (s *AuthService) RevokeOrganizationInvitation(ctx context.Context, userID, orgID, invitationID string) error {
+	if _, err := s.requireOrganizationRole(ctx, userID, orgID, OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.RespondToInvitation(ctx, orgID, invitationID, repository.InvitationRevoked); err != nil {
+		if errors.Is(err, repository.ErrInvitationNotFound) {
+			return ErrInvalidInvitation
+		}
+		return err
+	}
+	s.audit(ctx, AuditOrganizationInvite, AuditSuccess, "", map[string]string{"organization_id": orgID, "invitation_id": invitationID, "step": "revoke"})
+
+	return nil
+}
+
+// AcceptOrganizationInvitation adds the caller to the organization of an invitation sent to their
+// verified email address and returns the organization
+func (s *AuthService) AcceptOrganizationInvitation(ctx context.Context, userID, token string) (*Organization, error) {
+	invitation, err := s.invitationForUser(ctx, userID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.orgRepo.AcceptInvitation(ctx, invitation, userID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvitationNotFound):
+			return nil, ErrInvalidInvitation
+		case errors.Is(err, repository.ErrAlreadyMember):
+			return nil, ErrAlreadyOrganizationMember
+		default:
+			return nil, err
+		}
+	}
+	s.audit(ctx, AuditOrganizationInvite, AuditSuccess, userID, map[string]string{"organization_id": invitation.OrganizationID, "invitation_id": invitation.ID, "step": "accept"})
+
+	org, err := s.orgRepo.GetByID(ctx, invitation.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Organization invitation accepted", "organization_id", org.ID, "user_id", userID)
+	return convertOrganization(org, invitation.Role), nil
+}
+
+// DeclineOrganizationInvitation turns down an invitation sent to the caller's email address
+func (s *AuthService) DeclineOrganizationInvitation(ctx context.Context, userID, token string) error {
+	invitation, err := s.invitationForUser(ctx, userID, token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.RespondToInvitation(ctx, invitation.OrganizationID, invitation.ID, repository.InvitationDeclined); err != nil {
+		if errors.Is(err, repository.ErrInvitationNotFound) {
+			return ErrInvalidInvitation
+		}
+		return err
+	}
+	s.audit(ctx, AuditOrganizationInvite, AuditSuccess, userID, map[string]string{"organization_id": invitation.OrganizationID, "invitation_id": invitation.ID, "step": "decline"})
+
+	return nil
+}
+
+// SwitchOrganization makes orgID the active organization of the caller's session and returns a
+// new access token and refresh token carrying it; an empty orgID leaves no organization active.
+// The session's previous refresh token stops working. Tokens issued to OAuth2 clients never
+// carry an organization, so they cannot switch: the new tokens would be first-party ones with the
+// user's full authority.
+func (s *AuthService) SwitchOrganization(ctx context.Context, userID, sessionID, orgID string) (string, string, error) {
+	if clientID, _ := ctx.Value("clientID").(string); clientID != "" {
+		return "", "", ErrOrganizationClientToken
+	}
+	if sessionID == "" {
+		return "", "", ErrSessionNotFound
+	}
+
+	grant := tokenGrant{SessionID: sessionID}
+	if orgID != "" {
+		role, err := s.requireOrganizationRole(ctx, userID, orgID, OrgRoleMember)
+		if err != nil {
+			return "", "", err
+		}
+		grant.OrgID, grant.OrgRole = orgID, role
+	}
+
+	session, err := s.getSession(ctx, sessionID)
+	if err != nil || !session.Active() || session.UserID != userID {
+		return "", "", ErrSessionNotFound
+	}
+
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, tokenID, err := s.generateRefreshToken(user, grant)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	oldTokenID := session.RefreshTokenID
+	session.RefreshTokenID = tokenID
+	rotated, err := s.sessionRepo.RotateRefreshToken(ctx, session, oldTokenID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if !rotated {
+		return "", "", ErrSessionNotFound
+	}
+	s.cacheSession(ctx, session)
+
+	accessToken, err := s.generateAccessToken(user, grant)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+	s.audit(ctx, AuditOrganizationSwitch, AuditSuccess, userID, map[string]string{"organization_id": orgID})
+
+	return accessToken, refreshToken, nil
+}
+
+// organizationGrant returns the organization and role tokens of a session carry: orgID if the
+// user is still a member of it, otherwise none. With an empty orgID, first-party logins start in
+// the organization the user joined first.
+func (s *AuthService) organizationGrant(ctx context.Context, userID, orgID string) (string, string, error) {
+	if orgID == "" {
+		memberships, err := s.orgRepo.ListByUser(ctx, userID)
+		if err != nil || len(memberships) == 0 {
+			return "", "", err
+		}
+		return memberships[0].ID, memberships[0].Role, nil
+	}
+
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMemberNotFound) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+
+	return orgID, member.Role, nil
+}
+
+// requireOrganizationRole returns the caller's role in an organization if it is at least minRole.
+// Non-members get ErrOrganizationNotFound, so they cannot tell which organizations exist.
+func (s *AuthService) requireOrganizationRole(ctx context.Context, userID, orgID, minRole string) (string, error) {
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMemberNotFound) {
+			return "", ErrOrganizationNotFound
+		}
+		return "", err
+	}
+
+	if organizationRoleRank(member.Role) < organizationRoleRank(minRole) {
+		return "", ErrOrganizationForbidden
+	}
+
+	return member.Role, nil
+}
+
+func (s *AuthService) getOrganizationMember(ctx context.Context, orgID, userID string) (*repository.OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMemberNotFound) {
+			return nil, ErrOrganizationMemberNotFound
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// invitationForUser returns the pending invitation with token if it was sent to the user's email
+// address. The address must be verified, so nobody can sign up with someone else's address to
+// claim their invitations.
+func (s *AuthService) invitationForUser(ctx context.Context, userID, token string) (*repository.OrganizationInvitation, error) {
+	if token == "" {
+		return nil, ErrInvalidInvitation
+	}
+
+	invitation, err := s.orgRepo.GetInvitationByTokenHash(ctx, hashOneTimeToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrInvitationNotFound) {
+			return nil, ErrInvalidInvitation
+		}
+		return nil, err
+	}
+
+	repoUser, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if normalizeLoginEmail(repoUser.Email) != normalizeLoginEmail(invitation.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+	if !repoUser.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+
+	return invitation, nil
+}
+
+// mapOrganizationMemberError translates the errors of membership changes
+func mapOrganizationMemberError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrMemberNotFound):
+		return ErrOrganizationMemberNotFound
+	case errors.Is(err, repository.ErrLastOwner):
+		return ErrLastOrganizationOwner
+	default:
+		return err
+	}
+}
+
+func validOrganizationRole(role string) bool {
+	return role == OrgRoleOwner || role == OrgRoleAdmin || role == OrgRoleMember
+}
+
+// organizationRoleRank orders organization roles by privilege
+func organizationRoleRank(role string) int {
+	switch role {
+	case OrgRoleOwner:
+		return 3
+	case OrgRoleAdmin:
+		return 2
+	case OrgRoleMember:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func convertOrganization(org *repository.Organization, role string) *Organization {
+	return &Organization{
+		ID:        org.ID,
+		Name:      org.Name,
+		Slug:      org.Slug,
+		Role:      role,
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
+
+func convertOrganizationMember(member *repository.OrganizationMember) *OrganizationMember {
+	return &OrganizationMember{
+		UserID:    member.UserID,
+		Email:     member.Email,
+		FirstName: member.FirstName,
+		LastName:  member.LastName,
+		Role:      member.Role,
+		JoinedAt:  member.JoinedAt,
+	}
+}
+
+func convertOrganizationInvitation(invitation *repository.OrganizationInvitation) *OrganizationInvitation {
+	return &OrganizationInvitation{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		InvitedBy:      invitation.InvitedBy,
+		CreatedAt:      invitation.CreatedAt,
+		ExpiresAt:      invitation.ExpiresAt,
+	}
+}
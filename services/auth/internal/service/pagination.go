@@ -0,0 +1,37 @@
+package service
+
+// Pagination describes the page of a list that was returned, mirroring common.v1.PaginationResponse
+type Pagination struct {
+	Page       int  `json:"page"`
+	PageSize   int  `json:"page_size"`
+	TotalItems int  `json:"total_items"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// normalizePage applies the default page size and caps it at maxPageSize. Pages are 1-based.
+func normalizePage(page, pageSize, defaultPageSize, maxPageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+func newPagination(page, pageSize, totalItems int) *Pagination {
+	totalPages := (totalItems + pageSize - 1) / pageSize
+	return &Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/webauthn"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPasskeysUnavailable = errors.New("passkeys are unavailable")
+	ErrInvalidCeremony     = errors.New("passkey ceremony expired or not found")
+	ErrInvalidPasskey      = errors.New("invalid passkey")
+	ErrPasskeyNotFound     = errors.New("passkey not found")
+	ErrPasskeyExists       = errors.New("passkey is already registered")
+)
+
+const (
+	ceremonyRegistration = "registration"
+	ceremonyLogin        = "login"
+)
+
+// Passkey is a WebAuthn credential registered by a user
+type Passkey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	AAGUID     string     `json:"aaguid,omitempty"`
+	Transports []string   `json:"transports"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// PasskeyRegistration starts registering a passkey. The options are passed to
+// navigator.credentials.create and the result is sent back with the ceremony ID.
+type PasskeyRegistration struct {
+	CeremonyID string                    `json:"ceremony_id"`
+	PublicKey  *webauthn.CreationOptions `json:"public_key"`
+}
+
+// PasskeyLogin starts a passwordless login. The options are passed to navigator.credentials.get
+// and the result is sent back with the ceremony ID.
+type PasskeyLogin struct {
+	CeremonyID string                   `json:"ceremony_id"`
+	PublicKey  *webauthn.RequestOptions `json:"public_key"`
+}
+
+// ceremony is the server side state of a WebAuthn ceremony, kept in Redis until it completes
+type ceremony struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	UserID    string `json:"user_id,omitempty"`
+}
+
+// BeginPasskeyRegistration issues a challenge for registering a new passkey for a user
+func (s *AuthService) BeginPasskeyRegistration(ctx context.Context, userID string) (*PasskeyRegistration, error) {
+	if err := impersonationAllowed(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	credentials, err := s.webauthnRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep authenticators from creating a second passkey for the same account
+	exclude := make([]webauthn.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		exclude = append(exclude, webauthn.CredentialDescriptor{
+			Type:       "public-key",
+			ID:         credential.ID,
+			Transports: credential.Transports,
+		})
+	}
+
+	ceremonyID, challenge, err := s.startCeremony(ctx, ceremonyRegistration, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if displayName == "" {
+		displayName = user.Email
+	}
+
+	return &PasskeyRegistration{
+		CeremonyID: ceremonyID,
+		PublicKey:  s.relyingParty.CreationOptions(challenge, []byte(user.ID), user.Email, displayName, exclude),
+	}, nil
+}
+
+// FinishPasskeyRegistration verifies the authenticator's response and stores the new passkey
+func (s *AuthService) FinishPasskeyRegistration(ctx context.Context, userID, ceremonyID, name string, resp *webauthn.AttestationResponse) (*Passkey, error) {
+	state, err := s.finishCeremony(ctx, ceremonyRegistration, ceremonyID)
+	if err != nil {
+		return nil, err
+	}
+	if state.UserID != userID {
+		return nil, ErrInvalidCeremony
+	}
+
+	credential, err := s.relyingParty.VerifyRegistration(resp, state.Challenge)
+	if err != nil {
+		s.logger.Warn("Passkey registration rejected", "error", err, "user_id", userID)
+		return nil, ErrInvalidPasskey
+	}
+
+	id := webauthn.URLEncoding.EncodeToString(credential.ID)
+	if _, err := s.webauthnRepo.GetByID(ctx, id); err == nil {
+		return nil, ErrPasskeyExists
+	} else if !errors.Is(err, repository.ErrCredentialNotFound) {
+		return nil, err
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		name = "Passkey"
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+
+	aaguid := ""
+	if parsed, err := uuid.FromBytes(credential.AAGUID); err == nil && parsed != uuid.Nil {
+		aaguid = parsed.String()
+	}
+
+	repoCredential := &repository.WebAuthnCredential{
+		ID:         id,
+		UserID:     userID,
+		Name:       name,
+		PublicKey:  credential.PublicKey,
+		SignCount:  credential.SignCount,
+		AAGUID:     aaguid,
+		Transports: resp.Response.Transports,
+	}
+	if repoCredential.Transports == nil {
+		repoCredential.Transports = []string{}
+	}
+
+	if err := s.webauthnRepo.Create(ctx, repoCredential); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Passkey registered", "user_id", userID, "credential_id", id)
+	return convertPasskey(repoCredential), nil
+}
+
+// BeginPasskeyLogin issues a challenge for a passwordless login with any discoverable passkey
+func (s *AuthService) BeginPasskeyLogin(ctx context.Context) (*PasskeyLogin, error) {
+	ceremonyID, challenge, err := s.startCeremony(ctx, ceremonyLogin, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasskeyLogin{
+		CeremonyID: ceremonyID,
+		PublicKey:  s.relyingParty.RequestOptions(challenge),
+	}, nil
+}
+
+// FinishPasskeyLogin verifies a passkey assertion and logs its user in, issuing the same tokens
+// as Login. A user verifying passkey counts as both factors, so no TOTP code is asked for.
+func (s *AuthService) FinishPasskeyLogin(ctx context.Context, ceremonyID string, resp *webauthn.AssertionResponse) (*LoginResult, error) {
+	state, err := s.finishCeremony(ctx, ceremonyLogin, ceremonyID)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := s.webauthnRepo.GetByID(ctx, resp.RawID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCredentialNotFound) {
+			return nil, ErrInvalidPasskey
+		}
+		return nil, err
+	}
+
+	if resp.Response.UserHandle != "" {
+		userHandle, err := webauthn.URLEncoding.DecodeString(resp.Response.UserHandle)
+		if err != nil || string(userHandle) != credential.UserID {
+			return nil, ErrInvalidPasskey
+		}
+	}
+
+	signCount, err := s.relyingParty.VerifyAssertion(resp, state.Challenge, credential.PublicKey, credential.SignCount)
+	if err != nil {
+		s.logger.Warn("Passkey login rejected", "error", err, "credential_id", credential.ID)
+		s.audit(ctx, AuditLogin, AuditFailure, credential.UserID, loginDetails(loginMethodPasskey, "invalid_assertion"))
+		return nil, ErrInvalidPasskey
+	}
+
+	if err := s.webauthnRepo.UpdateSignCount(ctx, credential.ID, signCount); err != nil {
+		return nil, err
+	}
+
+	repoUser, err := s.userRepo.GetByID(ctx, credential.UserID)
+	if err != nil {
+		return nil, ErrInvalidPasskey
+	}
+	if !repoUser.EmailVerified && !s.allowUnverifiedLogin {
+		s.audit(ctx, AuditLogin, AuditFailure, repoUser.ID, loginDetails(loginMethodPasskey, "email_not_verified"))
+		return nil, ErrEmailNotVerified
+	}
+
+	user, err := s.GetProfile(ctx, credential.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshToken, _, err := s.issueTokens(ctx, user, tokenGrant{})
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, AuditLogin, AuditSuccess, user.ID, loginDetails(loginMethodPasskey, ""))
+
+	return &LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// ListPasskeys returns the passkeys registered by a user
+func (s *AuthService) ListPasskeys(ctx context.Context, userID string) ([]*Passkey, error) {
+	credentials, err := s.webauthnRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys := make([]*Passkey, 0, len(credentials))
+	for _, credential := range credentials {
+		passkeys = append(passkeys, convertPasskey(credential))
+	}
+
+	return passkeys, nil
+}
+
+// DeletePasskey removes a passkey of a user
+func (s *AuthService) DeletePasskey(ctx context.Context, userID, id string) error {
+	if err := impersonationAllowed(ctx); err != nil {
+		return err
+	}
+
+	if err := s.webauthnRepo.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, repository.ErrCredentialNotFound) {
+			return ErrPasskeyNotFound
+		}
+		return err
+	}
+
+	s.logger.Info("Passkey removed", "user_id", userID, "credential_id", id)
+	return nil
+}
+
+// startCeremony stores a new challenge in Redis and returns the ceremony ID and the challenge
+func (s *AuthService) startCeremony(ctx context.Context, ceremonyType, userID string) (string, string, error) {
+	if s.redisClient == nil {
+		return "", "", ErrPasskeysUnavailable
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return "", "", err
+	}
+
+	data, err := json.Marshal(&ceremony{Type: ceremonyType, Challenge: challenge, UserID: userID})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode ceremony: %w", err)
+	}
+
+	ceremonyID := uuid.New().String()
+	if err := s.redisClient.SetWithExpiry(ctx, ceremonyKey(ceremonyID), data, webauthn.Timeout); err != nil {
+		return "", "", fmt.Errorf("failed to store ceremony: %w", err)
+	}
+
+	return ceremonyID, challenge, nil
+}
+
+// finishCeremony consumes a stored ceremony; each challenge can only be answered once
+func (s *AuthService) finishCeremony(ctx context.Context, ceremonyType, ceremonyID string) (*ceremony, error) {
+	if s.redisClient == nil {
+		return nil, ErrPasskeysUnavailable
+	}
+
+	data, err := s.redisClient.GetDelete(ctx, ceremonyKey(ceremonyID))
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrInvalidCeremony
+		}
+		return nil, fmt.Errorf("failed to load ceremony: %w", err)
+	}
+
+	state := &ceremony{}
+	if err := json.Unmarshal([]byte(data), state); err != nil || state.Type != ceremonyType {
+		return nil, ErrInvalidCeremony
+	}
+
+	return state, nil
+}
+
+func convertPasskey(credential *repository.WebAuthnCredential) *Passkey {
+	return &Passkey{
+		ID:         credential.ID,
+		Name:       credential.Name,
+		AAGUID:     credential.AAGUID,
+		Transports: credential.Transports,
+		CreatedAt:  credential.CreatedAt,
+		LastUsedAt: credential.LastUsedAt,
+	}
+}
+
+func ceremonyKey(ceremonyID string) string {
+	return fmt.Sprintf("webauthn:ceremony:%s", ceremonyID)
+}
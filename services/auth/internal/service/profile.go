@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/mail"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+var (
+	ErrUserModified            = errors.New("user was modified by another request, reload and try again")
+	ErrIncorrectPassword       = errors.New("current password is incorrect")
+	ErrEmailUnchanged          = errors.New("new email address is the same as the current one")
+	ErrInvalidEmailChangeToken = errors.New("invalid or expired email change token")
+	ErrEmailTaken              = errors.New("email address is already in use by another account")
+)
+
+// ProfileUpdate holds the profile fields a user changes; nil fields are left alone
+type ProfileUpdate struct {
+	FirstName *string
+	LastName  *string
+	// UpdatedAt is the updated_at value the client last read; the update fails with
+	// ErrUserModified if the user changed since. The zero time skips the check.
+	UpdatedAt time.Time
+}
+
+// UpdateProfile changes the caller's own name
+func (s *AuthService) UpdateProfile(ctx context.Context, userID string, update ProfileUpdate) (*User, error) {
+	repoUser, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := checkUnmodified(repoUser.UpdatedAt, update.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	changed := map[string]string{}
+	if update.FirstName != nil {
+		repoUser.FirstName = *update.FirstName
+		changed["first_name"] = "changed"
+	}
+	if update.LastName != nil {
+		repoUser.LastName = *update.LastName
+		changed["last_name"] = "changed"
+	}
+
+	if err := s.userRepo.Update(ctx, repoUser); err != nil {
+		return nil, mapUserUpdateError(err)
+	}
+	s.audit(ctx, AuditProfileUpdate, AuditSuccess, userID, changed)
+
+	return s.GetProfile(ctx, userID)
+}
+
+// ChangePassword replaces the caller's password after checking the current one, and revokes
+// every other session of the user so stolen credentials stop working. The session the change
+// is made from (sessionID, which may be empty) stays logged in.
+func (s *AuthService) ChangePassword(ctx context.Context, userID, sessionID, currentPassword, newPassword string) error {
+	if err := impersonationAllowed(ctx); err != nil {
+		return err
+	}
+
+	repoUser, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if !s.verifyPassword(repoUser, currentPassword) {
+		s.audit(ctx, AuditPasswordChange, AuditFailure, userID, map[string]string{"method": "change", "reason": "bad_password"})
+		return ErrIncorrectPassword
+	}
+
+	if err := s.passwordPolicy.Check(newPassword, repoUser.Email, repoUser.FirstName, repoUser.LastName); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, userID, hashedPassword, repoUser.UpdatedAt); err != nil {
+		return mapUserUpdateError(err)
+	}
+
+	if _, err := s.RevokeAllSessions(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	s.audit(ctx, AuditPasswordChange, AuditSuccess, userID, map[string]string{"method": "change"})
+
+	s.logger.Info("Password changed", "user_id", userID)
+	return nil
+}
+
+// RequestEmailChange emails a confirmation link to newEmail. The caller's address only changes
+// once the link is followed, so a typo cannot take over someone else's mailbox.
+func (s *AuthService) RequestEmailChange(ctx context.Context, userID, currentPassword, newEmail string) error {
+	if err := impersonationAllowed(ctx); err != nil {
+		return err
+	}
+
+	repoUser, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if !s.verifyPassword(repoUser, currentPassword) {
+		s.audit(ctx, AuditEmailChange, AuditFailure, userID, map[string]string{"step": "request", "reason": "bad_password"})
+		return ErrIncorrectPassword
+	}
+
+	if normalizeLoginEmail(newEmail) == normalizeLoginEmail(repoUser.Email) {
+		return ErrEmailUnchanged
+	}
+
+	exists, err := s.userRepo.EmailExists(ctx, newEmail)
+	if err != nil {
+		return fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if exists {
+		return ErrEmailTaken
+	}
+
+	token, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		return err
+	}
+
+	err = s.emailChangeRepo.Create(ctx, &repository.EmailChangeToken{
+		UserID:    userID,
+		NewEmail:  newEmail,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(s.verifyExpiry),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store email change token: %w", err)
+	}
+
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your new email address using the link below. It expires in %s.\n\n%s\n\nIf you did not ask to change your email address, you can ignore this email.\n",
+			repoUser.FirstName, s.verifyExpiry, linkWithToken(s.emailChangeURL, token)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email change confirmation: %w", err)
+	}
+
+	s.audit(ctx, AuditEmailChange, AuditSuccess, userID, map[string]string{"step": "request"})
+	return nil
+}
+
+// ConfirmEmailChange moves a user to the new address of a RequestEmailChange token and tells the
+// old address about it
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrInvalidEmailChangeToken
+	}
+
+	change, err := s.emailChangeRepo.Consume(ctx, hashOneTimeToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrEmailChangeTokenNotFound) {
+			return ErrInvalidEmailChangeToken
+		}
+		if errors.Is(err, repository.ErrEmailTaken) {
+			return ErrEmailTaken
+		}
+		return fmt.Errorf("failed to change email: %w", err)
+	}
+	s.audit(ctx, AuditEmailChange, AuditSuccess, change.UserID, map[string]string{"step": "confirm"})
+
+	// The change is done either way; the notice only warns the owner of the old address
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      change.OldEmail,
+		Subject: "Your email address was changed",
+		Body:    "Hi,\n\nThe email address of your account was just changed. If you did not make this change, please contact support immediately.\n",
+	})
+	if err != nil {
+		s.logger.Error("Failed to send email change notice", "error", err, "user_id", change.UserID)
+	}
+
+	s.logger.Info("Email changed", "user_id", change.UserID)
+	return nil
+}
+
+// checkUnmodified compares the updated_at value a client last read with the current one, at the
+// precision the client has it; timestamps sent over gRPC are whole seconds
+func checkUnmodified(current, seen time.Time) error {
+	if seen.IsZero() {
+		return nil
+	}
+	if seen.Equal(seen.Truncate(time.Second)) {
+		current = current.Truncate(time.Second)
+	}
+	if !current.Equal(seen) {
+		return ErrUserModified
+	}
+	return nil
+}
+
+// mapUserUpdateError translates the errors of conditional user updates
+func mapUserUpdateError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+		return ErrUserNotFound
+	case errors.Is(err, repository.ErrUserModified):
+		return ErrUserModified
+	case errors.Is(err, repository.ErrEmailTaken):
+		return ErrEmailTaken
+	default:
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+}
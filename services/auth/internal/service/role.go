@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+// Built-in roles seeded by the migrations
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Built-in permissions seeded by the migrations
+const (
+	PermissionUsersRead   = "users:read"
+	PermissionUsersWrite  = "users:write"
+	PermissionRolesManage = "roles:manage"
+
+	PermissionServiceAccountsManage = "service_accounts:manage"
+	PermissionAuditRead             = "audit:read"
+)
+
+var (
+	ErrRoleNotFound    = errors.New("role not found")
+	ErrRoleNotAssigned = errors.New("role not assigned to user")
+)
+
+// GetUserRoles returns the roles assigned to a user
+func (s *AuthService) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	roles, err := s.roleRepo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// AssignRole grants a role to a user and returns the user's resulting roles.
+// Tokens issued before the change keep their old roles until they expire.
+func (s *AuthService) AssignRole(ctx context.Context, userID, role string) ([]string, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	exists, err := s.roleRepo.RoleExists(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check role: %w", err)
+	}
+	if !exists {
+		return nil, ErrRoleNotFound
+	}
+
+	if err := s.roleRepo.AssignRole(ctx, userID, role); err != nil {
+		return nil, fmt.Errorf("failed to assign role: %w", err)
+	}
+	s.audit(ctx, AuditRoleAssign, AuditSuccess, userID, map[string]string{"role": role})
+
+	return s.GetUserRoles(ctx, userID)
+}
+
+// RemoveRole revokes a role from a user and returns the user's remaining roles
+func (s *AuthService) RemoveRole(ctx context.Context, userID, role string) ([]string, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.roleRepo.RemoveRole(ctx, userID, role); err != nil {
+		if errors.Is(err, repository.ErrRoleNotAssigned) {
+			return nil, ErrRoleNotAssigned
+		}
+		return nil, fmt.Errorf("failed to remove role: %w", err)
+	}
+	s.audit(ctx, AuditRoleRemove, AuditSuccess, userID, map[string]string{"role": role})
+
+	return s.GetUserRoles(ctx, userID)
+}
+
+// BootstrapAdmin grants the admin role to the user with email, so a fresh deployment has someone
+// who can manage roles. It is idempotent and meant to run at startup.
+func (s *AuthService) BootstrapAdmin(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if err := s.roleRepo.AssignRole(ctx, user.ID, RoleAdmin); err != nil {
+		return fmt.Errorf("failed to assign admin role: %w", err)
+	}
+
+	s.audit(ctx, AuditRoleAssign, AuditSuccess, user.ID, map[string]string{"role": RoleAdmin, "method": "bootstrap"})
+	s.logger.Info("Bootstrap admin role granted", "user_id", user.ID)
+	return nil
+}
+
+// loadAuthorization fills in the roles and permissions of user
+func (s *AuthService) loadAuthorization(ctx context.Context, user *User) error {
+	roles, err := s.roleRepo.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	permissions, err := s.roleRepo.GetUserPermissions(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	user.Roles = roles
+	user.Permissions = permissions
+	return nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrInvalidScope           = errors.New("invalid scope")
+)
+
+// ServiceAccount is a non-human principal that authenticates with a client ID and secret.
+// Its scopes are permission names and bound what its access tokens may do.
+type ServiceAccount struct {
+	ID        string    `json:"id"`
+	ClientID  string    `json:"client_id"`
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateServiceAccount registers a service account allowed to request scopes and returns it
+// with its client secret. The secret is only stored hashed and cannot be retrieved later.
+func (s *AuthService) CreateServiceAccount(ctx context.Context, name string, scopes []string) (*ServiceAccount, string, error) {
+	if err := s.validateScopes(ctx, scopes); err != nil {
+		return nil, "", err
+	}
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	secret, secretHash, err := generateClientSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	account := &repository.ServiceAccount{
+		ClientID:   "sa-" + uuid.New().String(),
+		SecretHash: secretHash,
+		Name:       name,
+		Scopes:     scopes,
+		Active:     true,
+	}
+
+	if err := s.accountRepo.Create(ctx, account); err != nil {
+		return nil, "", fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	s.logger.Info("Service account created", "id", account.ID, "client_id", account.ClientID)
+
+	return convertServiceAccount(account), secret, nil
+}
+
+// ListServiceAccounts returns every active service account
+func (s *AuthService) ListServiceAccounts(ctx context.Context) ([]*ServiceAccount, error) {
+	accounts, err := s.accountRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	result := make([]*ServiceAccount, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, convertServiceAccount(account))
+	}
+
+	return result, nil
+}
+
+// RotateServiceAccountSecret replaces the secret of a service account and returns the new one.
+// Access tokens already issued stay valid until they expire.
+func (s *AuthService) RotateServiceAccountSecret(ctx context.Context, id string) (string, error) {
+	secret, secretHash, err := generateClientSecret()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.accountRepo.UpdateSecret(ctx, id, secretHash); err != nil {
+		if errors.Is(err, repository.ErrServiceAccountNotFound) {
+			return "", ErrServiceAccountNotFound
+		}
+		return "", fmt.Errorf("failed to rotate service account secret: %w", err)
+	}
+
+	s.logger.Info("Service account secret rotated", "id", id)
+
+	return secret, nil
+}
+
+// DeleteServiceAccount deactivates a service account so it can no longer obtain tokens
+func (s *AuthService) DeleteServiceAccount(ctx context.Context, id string) error {
+	if err := s.accountRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrServiceAccountNotFound) {
+			return ErrServiceAccountNotFound
+		}
+		return fmt.Errorf("failed to delete service account: %w", err)
+	}
+
+	s.logger.Info("Service account deleted", "id", id)
+
+	return nil
+}
+
+// ClientCredentialsToken implements the client_credentials grant (RFC 6749 section 4.4) for service accounts.
+// An empty scope requests every scope of the account. No refresh token is issued.
+func (s *AuthService) ClientCredentialsToken(ctx context.Context, clientID, clientSecret, scope string) (*TokenResponse, error) {
+	account, err := s.accountRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, repository.ErrServiceAccountNotFound) {
+			return nil, newOAuthError("invalid_client", "unknown client")
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(account.SecretHash), []byte(clientSecret)); err != nil {
+		return nil, newOAuthError("invalid_client", "client authentication failed")
+	}
+
+	scopes := strings.Fields(scope)
+	if len(scopes) == 0 {
+		scopes = account.Scopes
+	}
+	for _, requested := range scopes {
+		if !contains(account.Scopes, requested) {
+			return nil, newOAuthError("invalid_scope", fmt.Sprintf("scope %q is not allowed for this client", requested))
+		}
+	}
+	scope = strings.Join(scopes, " ")
+
+	accessToken, err := s.generateServiceToken(account, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return &TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.tokenExpiry.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+// generateServiceToken issues an access token for a service account. It carries no user ID,
+// which is how middleware tells service accounts apart from users.
+func (s *AuthService) generateServiceToken(account *repository.ServiceAccount, scope string) (string, error) {
+	claims := &JWTClaims{
+		TokenType: tokenTypeAccess,
+		Scope:     scope,
+		ClientID:  account.ClientID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.tokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   account.ID,
+		},
+	}
+
+	return s.keys.Sign(claims)
+}
+
+// validateScopes checks that every scope names a known permission
+func (s *AuthService) validateScopes(ctx context.Context, scopes []string) error {
+	for _, scope := range scopes {
+		exists, err := s.roleRepo.PermissionExists(ctx, scope)
+		if err != nil {
+			return fmt.Errorf("failed to check scope: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+
+	return nil
+}
+
+// generateClientSecret returns a random client secret and its bcrypt hash
+func generateClientSecret() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate client secret: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to hash client secret: %w", err)
+	}
+
+	return secret, string(hash), nil
+}
+
+func convertServiceAccount(account *repository.ServiceAccount) *ServiceAccount {
+	return &ServiceAccount{
+		ID:        account.ID,
+		ClientID:  account.ClientID,
+		Name:      account.Name,
+		Scopes:    account.Scopes,
+		CreatedAt: account.CreatedAt,
+		UpdatedAt: account.UpdatedAt,
+	}
+}
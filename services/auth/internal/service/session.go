@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VariableSan/go-factory-microservice/pkg/common/redis"
+	"github.com/VariableSan/go-factory-microservice/services/auth/internal/repository"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// ClientInfo describes the device a request originates from
+type ClientInfo struct {
+	IPAddress  string
+	UserAgent  string
+	DeviceName string
+	// RequestID correlates audit events with request logs
+	RequestID string
+}
+
+type clientInfoKey struct{}
+
+// ContextWithClientInfo attaches the caller's client information to ctx
+func ContextWithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// ClientInfoFromContext returns the caller's client information attached by ContextWithClientInfo
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+type Session struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// ListSessions returns the active sessions of a user, flagging currentSessionID as the current one
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]*Session, error) {
+	repoSessions, err := s.sessionRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*Session, 0, len(repoSessions))
+	for _, repoSession := range repoSessions {
+		sessions = append(sessions, &Session{
+			ID:         repoSession.ID,
+			DeviceName: repoSession.DeviceName,
+			UserAgent:  repoSession.UserAgent,
+			IPAddress:  repoSession.IPAddress,
+			CreatedAt:  repoSession.CreatedAt,
+			LastSeenAt: repoSession.LastSeenAt,
+			ExpiresAt:  repoSession.ExpiresAt,
+			Current:    repoSession.ID == currentSessionID,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes one session of a user so neither its refresh token nor its access tokens can be used
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if err := s.sessionRepo.Revoke(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	s.evictSession(ctx, sessionID)
+	s.denySession(ctx, sessionID)
+	return nil
+}
+
+// RevokeAllSessions revokes every session of a user except exceptSessionID (which may be empty),
+// along with every impersonation token in which the user is the impersonated user or the
+// administrator, and returns the number of revoked sessions
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID, exceptSessionID string) (int, error) {
+	ids, err := s.sessionRepo.RevokeAllByUser(ctx, userID, exceptSessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	for _, id := range ids {
+		s.evictSession(ctx, id)
+		s.denySession(ctx, id)
+	}
+	s.denyImpersonations(ctx, userID)
+
+	return len(ids), nil
+}
+
+// createSession persists a new session for user whose first refresh token is refreshTokenID
+func (s *AuthService) createSession(ctx context.Context, sessionID, userID, refreshTokenID string) error {
+	client := ClientInfoFromContext(ctx)
+	session := &repository.Session{
+		ID:             sessionID,
+		UserID:         userID,
+		RefreshTokenID: refreshTokenID,
+		DeviceName:     client.DeviceName,
+		UserAgent:      client.UserAgent,
+		IPAddress:      client.IPAddress,
+		ExpiresAt:      time.Now().Add(s.refreshExpiry),
+	}
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return err
+	}
+
+	s.cacheSession(ctx, session)
+	return nil
+}
+
+// getSession loads a session from the Redis cache, falling back to Postgres
+func (s *AuthService) getSession(ctx context.Context, sessionID string) (*repository.Session, error) {
+	if s.redisClient != nil {
+		cached, err := s.redisClient.GetString(ctx, sessionKey(sessionID))
+		if err == nil {
+			session := &repository.Session{}
+			if err := json.Unmarshal([]byte(cached), session); err == nil {
+				return session, nil
+			}
+		} else if err != redis.Nil {
+			s.logger.Warn("Failed to read session from Redis", "error", err)
+		}
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	s.cacheSession(ctx, session)
+	return session, nil
+}
+
+// cacheSession stores an active session in Redis until it expires
+func (s *AuthService) cacheSession(ctx context.Context, session *repository.Session) {
+	if s.redisClient == nil || !session.Active() {
+		return
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		s.logger.Warn("Failed to encode session for Redis", "error", err)
+		return
+	}
+
+	if err := s.redisClient.SetWithExpiry(ctx, sessionKey(session.ID), data, time.Until(session.ExpiresAt)); err != nil {
+		s.logger.Warn("Failed to cache session in Redis", "error", err)
+	}
+}
+
+// evictSession drops a session from the Redis cache
+func (s *AuthService) evictSession(ctx context.Context, sessionID string) {
+	if s.redisClient == nil {
+		return
+	}
+
+	if err := s.redisClient.Delete(ctx, sessionKey(sessionID)); err != nil {
+		s.logger.Warn("Failed to evict session from Redis", "error", err)
+	}
+}
+
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
+}
@@ -0,0 +1,70 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 6238 test vectors, "12345678901234567890", base32 encoded
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidate(t *testing.T) {
+	// The RFC 6238 vectors list 8 digits; these are their last 6
+	at := time.Unix(1111111109, 0)
+	step := at.Unix() / int64(Period.Seconds())
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		t        time.Time
+		want     bool
+		wantStep int64
+	}{
+		{name: "rfc 6238 vector", secret: rfcSecret, code: "081804", t: at, want: true, wantStep: step},
+		{name: "rfc 6238 vector at epoch", secret: rfcSecret, code: "287082", t: time.Unix(59, 0), want: true, wantStep: 1},
+		{name: "lowercase secret", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code: "081804", t: at, want: true, wantStep: step},
+		{name: "previous step", secret: rfcSecret, code: "081804", t: at.Add(Period), want: true, wantStep: step},
+		{name: "next step", secret: rfcSecret, code: "081804", t: at.Add(-Period), want: true, wantStep: step},
+		{name: "two steps later", secret: rfcSecret, code: "081804", t: at.Add(2 * Period)},
+		{name: "two steps earlier", secret: rfcSecret, code: "081804", t: at.Add(-2 * Period)},
+		{name: "wrong code", secret: rfcSecret, code: "081805", t: at},
+		{name: "too short", secret: rfcSecret, code: "81804", t: at},
+		{name: "too long", secret: rfcSecret, code: "0081804", t: at},
+		{name: "empty code", secret: rfcSecret, code: "", t: at},
+		{name: "invalid secret", secret: "not base32!", code: "081804", t: at},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := Validate(tt.secret, tt.code, tt.t)
+			if ok != tt.want {
+				t.Fatalf("Validate() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && gotStep != tt.wantStep {
+				t.Errorf("Validate() step = %d, want %d", gotStep, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	key, err := encoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret %q is not base32: %v", secret, err)
+	}
+	if len(key) != 20 {
+		t.Errorf("secret has %d bytes, want 20", len(key))
+	}
+
+	now := time.Now()
+	code := generate(key, now.Unix()/int64(Period.Seconds()))
+	if _, ok := Validate(secret, code, now); !ok {
+		t.Errorf("Validate() rejected the current code of a generated secret")
+	}
+}
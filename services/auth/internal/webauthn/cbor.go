@@ -0,0 +1,145 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errMalformedCBOR = errors.New("malformed CBOR")
+
+// maxCBORDepth bounds nesting so hostile input cannot exhaust the stack
+const maxCBORDepth = 16
+
+// decodeCBOR decodes the first CBOR data item in data and returns it with the remaining bytes.
+// It covers the subset authenticators emit (CTAP2 canonical encoding): integers become int64,
+// byte strings []byte, text strings string, arrays []interface{} and maps
+// map[interface{}]interface{}. Indefinite-length items are rejected.
+func decodeCBOR(data []byte) (interface{}, []byte, error) {
+	return decodeItem(data, 0)
+}
+
+func decodeItem(data []byte, depth int) (interface{}, []byte, error) {
+	if depth > maxCBORDepth {
+		return nil, nil, fmt.Errorf("%w: nested too deeply", errMalformedCBOR)
+	}
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("%w: unexpected end of data", errMalformedCBOR)
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+
+	// Floats and simple values carry their payload in the argument
+	if major == 7 {
+		return decodeSimple(data, info)
+	}
+
+	arg, rest, err := readArgument(data[1:], info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: integer overflow", errMalformedCBOR)
+		}
+		return int64(arg), rest, nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: integer overflow", errMalformedCBOR)
+		}
+		return -1 - int64(arg), rest, nil
+	case 2, 3:
+		if arg > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("%w: string exceeds data", errMalformedCBOR)
+		}
+		if major == 2 {
+			return append([]byte(nil), rest[:arg]...), rest[arg:], nil
+		}
+		return string(rest[:arg]), rest[arg:], nil
+	case 4:
+		if arg > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("%w: array exceeds data", errMalformedCBOR)
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			var item interface{}
+			item, rest, err = decodeItem(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, rest, nil
+	case 5:
+		if arg > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("%w: map exceeds data", errMalformedCBOR)
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			var key, value interface{}
+			key, rest, err = decodeItem(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, fmt.Errorf("%w: unsupported map key type", errMalformedCBOR)
+			}
+			value, rest, err = decodeItem(rest, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[key] = value
+		}
+		return m, rest, nil
+	default:
+		// Tags only annotate the item that follows
+		return decodeItem(rest, depth+1)
+	}
+}
+
+// readArgument reads the argument of an initial byte with additional information info
+func readArgument(data []byte, info byte) (uint64, []byte, error) {
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24 && len(data) >= 1:
+		return uint64(data[0]), data[1:], nil
+	case info == 25 && len(data) >= 2:
+		return uint64(binary.BigEndian.Uint16(data)), data[2:], nil
+	case info == 26 && len(data) >= 4:
+		return uint64(binary.BigEndian.Uint32(data)), data[4:], nil
+	case info == 27 && len(data) >= 8:
+		return binary.BigEndian.Uint64(data), data[8:], nil
+	case info == 31:
+		return 0, nil, fmt.Errorf("%w: indefinite length items are not supported", errMalformedCBOR)
+	default:
+		return 0, nil, fmt.Errorf("%w: invalid argument", errMalformedCBOR)
+	}
+}
+
+func decodeSimple(data []byte, info byte) (interface{}, []byte, error) {
+	rest := data[1:]
+	switch info {
+	case 20:
+		return false, rest, nil
+	case 21:
+		return true, rest, nil
+	case 22, 23:
+		return nil, rest, nil
+	case 25, 26, 27:
+		// Floats are never meaningful in WebAuthn structures; skip them
+		size := 1 << (info - 24)
+		if len(rest) < size {
+			return nil, nil, fmt.Errorf("%w: unexpected end of data", errMalformedCBOR)
+		}
+		return nil, rest[size:], nil
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported simple value", errMalformedCBOR)
+	}
+}
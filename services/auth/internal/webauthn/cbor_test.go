@@ -0,0 +1,135 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// cborMap is a CBOR map for encodeCBOR, keeping its entries in order
+type cborMap []cborEntry
+
+type cborEntry struct {
+	key, value interface{}
+}
+
+// encodeCBOR encodes the values decodeCBOR produces, for building test input
+func encodeCBOR(v interface{}) []byte {
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return cborHead(1, uint64(-1-v))
+		}
+		return cborHead(0, uint64(v))
+	case []byte:
+		return append(cborHead(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHead(3, uint64(len(v))), v...)
+	case []interface{}:
+		out := cborHead(4, uint64(len(v)))
+		for _, item := range v {
+			out = append(out, encodeCBOR(item)...)
+		}
+		return out
+	case cborMap:
+		out := cborHead(5, uint64(len(v)))
+		for _, entry := range v {
+			out = append(out, encodeCBOR(entry.key)...)
+			out = append(out, encodeCBOR(entry.value)...)
+		}
+		return out
+	case bool:
+		if v {
+			return []byte{0xf5}
+		}
+		return []byte{0xf4}
+	default:
+		panic("encodeCBOR: unsupported type")
+	}
+}
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, n)
+	}
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	nested := []byte{0x81}
+	for i := 0; i < maxCBORDepth+1; i++ {
+		nested = append(nested, 0x81)
+	}
+	nested = append(nested, 0x00)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		want     interface{}
+		wantRest []byte
+		wantErr  bool
+	}{
+		{name: "small integer", data: []byte{0x17}, want: int64(23)},
+		{name: "one byte integer", data: []byte{0x18, 0xff}, want: int64(255)},
+		{name: "eight byte integer", data: encodeCBOR(1 << 40), want: int64(1 << 40)},
+		{name: "negative integer", data: encodeCBOR(-257), want: int64(-257)},
+		{name: "byte string", data: encodeCBOR([]byte{1, 2, 3}), want: []byte{1, 2, 3}},
+		{name: "text string", data: encodeCBOR("fmt"), want: "fmt"},
+		{name: "array", data: encodeCBOR([]interface{}{1, "a"}), want: []interface{}{int64(1), "a"}},
+		{
+			name: "map",
+			data: encodeCBOR(cborMap{{1, 2}, {"authData", []byte{9}}}),
+			want: map[interface{}]interface{}{int64(1): int64(2), "authData": []byte{9}},
+		},
+		{name: "booleans", data: encodeCBOR([]interface{}{true, false}), want: []interface{}{true, false}},
+		{name: "null", data: []byte{0xf6}, want: nil},
+		{name: "float is skipped", data: []byte{0xf9, 0x3c, 0x00}, want: nil},
+		{name: "tag annotates the next item", data: []byte{0xc2, 0x41, 0x01}, want: []byte{1}},
+		{name: "remaining bytes", data: []byte{0x01, 0x02, 0x03}, want: int64(1), wantRest: []byte{0x02, 0x03}},
+		{name: "empty", data: []byte{}, wantErr: true},
+		{name: "truncated argument", data: []byte{0x19, 0x01}, wantErr: true},
+		{name: "reserved argument", data: []byte{0x1c}, wantErr: true},
+		{name: "indefinite length", data: []byte{0x5f, 0x41, 0x01, 0xff}, wantErr: true},
+		{name: "integer overflow", data: []byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, wantErr: true},
+		{name: "negative integer overflow", data: []byte{0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, wantErr: true},
+		{name: "string exceeds data", data: []byte{0x45, 0x01, 0x02}, wantErr: true},
+		{name: "array exceeds data", data: []byte{0x9a, 0xff, 0xff, 0xff, 0xff}, wantErr: true},
+		{name: "map exceeds data", data: []byte{0xba, 0xff, 0xff, 0xff, 0xff}, wantErr: true},
+		{name: "truncated array", data: []byte{0x82, 0x01}, wantErr: true},
+		{name: "map with array key", data: []byte{0xa1, 0x80, 0x01}, wantErr: true},
+		{name: "map without value", data: []byte{0xa1, 0x01}, wantErr: true},
+		{name: "truncated float", data: []byte{0xfa, 0x00}, wantErr: true},
+		{name: "unsupported simple value", data: []byte{0xf0}, wantErr: true},
+		{name: "nested too deeply", data: nested, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := decodeCBOR(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, errMalformedCBOR) {
+					t.Fatalf("decodeCBOR() error = %v, want %v", err, errMalformedCBOR)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeCBOR() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCBOR() = %#v, want %#v", got, tt.want)
+			}
+			if len(rest) != len(tt.wantRest) || (len(rest) > 0 && !reflect.DeepEqual(rest, tt.wantRest)) {
+				t.Errorf("decodeCBOR() rest = %x, want %x", rest, tt.wantRest)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers offered to authenticators, in order of preference
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// COSE key parameters (RFC 9053)
+const (
+	coseKeyType   = 1
+	coseAlgorithm = 3
+	coseCurve     = -1
+	coseX         = -2 // also the RSA modulus n
+	coseY         = -3 // also the RSA exponent e
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+var ErrUnsupportedKey = errors.New("unsupported credential public key")
+
+// publicKey is a credential public key decoded from its COSE encoding
+type publicKey struct {
+	algorithm int64
+	key       crypto.PublicKey
+}
+
+// parsePublicKey decodes a COSE_Key, accepting ES256, EdDSA (Ed25519) and RS256 keys
+func parsePublicKey(data []byte) (*publicKey, error) {
+	decoded, _, err := decodeCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+
+	keyType, _ := m[int64(coseKeyType)].(int64)
+	alg, _ := m[int64(coseAlgorithm)].(int64)
+	x, _ := m[int64(coseX)].([]byte)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && alg == AlgES256:
+		y, _ := m[int64(coseY)].([]byte)
+		if curve, _ := m[int64(coseCurve)].(int64); curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, ErrUnsupportedKey
+		}
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, ErrUnsupportedKey
+		}
+		return &publicKey{algorithm: alg, key: key}, nil
+
+	case keyType == coseKeyTypeOKP && alg == AlgEdDSA:
+		if curve, _ := m[int64(coseCurve)].(int64); curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, ErrUnsupportedKey
+		}
+		return &publicKey{algorithm: alg, key: ed25519.PublicKey(x)}, nil
+
+	case keyType == coseKeyTypeRSA && alg == AlgRS256:
+		e, _ := m[int64(coseY)].([]byte)
+		if len(x) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, ErrUnsupportedKey
+		}
+		return &publicKey{algorithm: alg, key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(x),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: key type %d, algorithm %d", ErrUnsupportedKey, keyType, alg)
+	}
+}
+
+// verify checks signature over message
+func (k *publicKey) verify(message, signature []byte) bool {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,161 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// es256Key returns the COSE_Key encoding of key
+func es256Key(key *ecdsa.PublicKey) []byte {
+	return encodeCBOR(cborMap{
+		{coseKeyType, coseKeyTypeEC2},
+		{coseAlgorithm, AlgES256},
+		{coseCurve, coseCurveP256},
+		{coseX, key.X.FillBytes(make([]byte, 32))},
+		{coseY, key.Y.FillBytes(make([]byte, 32))},
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := ecKey.X.FillBytes(make([]byte, 32))
+	y := ecKey.Y.FillBytes(make([]byte, 32))
+	offCurve := new(big.Int).Add(ecKey.Y, big.NewInt(1)).FillBytes(make([]byte, 32))
+	exponent := big.NewInt(int64(rsaKey.E)).Bytes()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantAlg int64
+		wantErr error
+	}{
+		{name: "es256", data: es256Key(&ecKey.PublicKey), wantAlg: AlgES256},
+		{
+			name:    "eddsa",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeOKP}, {coseAlgorithm, AlgEdDSA}, {coseCurve, coseCurveEd25519}, {coseX, []byte(edKey)}}),
+			wantAlg: AlgEdDSA,
+		},
+		{
+			name:    "rs256",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeRSA}, {coseAlgorithm, AlgRS256}, {coseX, rsaKey.N.Bytes()}, {coseY, exponent}}),
+			wantAlg: AlgRS256,
+		},
+		{name: "not cbor", data: []byte{0x5f}, wantErr: errMalformedCBOR},
+		{name: "not a map", data: encodeCBOR([]interface{}{1}), wantErr: ErrUnsupportedKey},
+		{
+			name:    "es256 on another curve",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeEC2}, {coseAlgorithm, AlgES256}, {coseCurve, 2}, {coseX, x}, {coseY, y}}),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "es256 point off the curve",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeEC2}, {coseAlgorithm, AlgES256}, {coseCurve, coseCurveP256}, {coseX, x}, {coseY, offCurve}}),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "es256 short coordinate",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeEC2}, {coseAlgorithm, AlgES256}, {coseCurve, coseCurveP256}, {coseX, x[1:]}, {coseY, y}}),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "eddsa short key",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeOKP}, {coseAlgorithm, AlgEdDSA}, {coseCurve, coseCurveEd25519}, {coseX, []byte(edKey)[1:]}}),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "rs256 short modulus",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeRSA}, {coseAlgorithm, AlgRS256}, {coseX, rsaKey.N.Bytes()[:128]}, {coseY, exponent}}),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "rs256 without exponent",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeRSA}, {coseAlgorithm, AlgRS256}, {coseX, rsaKey.N.Bytes()}}),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "key type and algorithm mismatch",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeEC2}, {coseAlgorithm, AlgRS256}, {coseCurve, coseCurveP256}, {coseX, x}, {coseY, y}}),
+			wantErr: ErrUnsupportedKey,
+		},
+		{
+			name:    "unsupported algorithm",
+			data:    encodeCBOR(cborMap{{coseKeyType, coseKeyTypeEC2}, {coseAlgorithm, -35}, {coseCurve, 2}, {coseX, x}, {coseY, y}}),
+			wantErr: ErrUnsupportedKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := parsePublicKey(tt.data)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("parsePublicKey() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePublicKey() error = %v", err)
+			}
+			if key.algorithm != tt.wantAlg {
+				t.Errorf("parsePublicKey() algorithm = %d, want %d", key.algorithm, tt.wantAlg)
+			}
+		})
+	}
+}
+
+func TestPublicKeyVerify(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := parsePublicKey(es256Key(&ecKey.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte("signed data")
+	digest := sha256.Sum256(message)
+	signature, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		message   []byte
+		signature []byte
+		want      bool
+	}{
+		{name: "valid", message: message, signature: signature, want: true},
+		{name: "other message", message: []byte("other data"), signature: signature},
+		{name: "truncated signature", message: message, signature: signature[:len(signature)-1]},
+		{name: "empty signature", message: message},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := key.verify(tt.message, tt.signature); got != tt.want {
+				t.Errorf("verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Timeout is how long a ceremony may take, both in the browser and for the stored challenge
+const Timeout = 5 * time.Minute
+
+// Authenticator data flags (WebAuthn Level 2, section 6.1)
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+)
+
+var (
+	ErrInvalidCredential = errors.New("invalid WebAuthn credential")
+	ErrClonedCredential  = errors.New("WebAuthn signature counter went backwards, the authenticator may be cloned")
+)
+
+// URLEncoding is the base64url encoding WebAuthn uses in JSON
+var URLEncoding = base64.RawURLEncoding
+
+// RelyingParty verifies WebAuthn ceremonies for one relying party ID. Passkeys are bound to the
+// RP ID (a registrable domain such as example.com) and only accepted from the listed origins.
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// Credential is a newly registered public key credential
+type Credential struct {
+	ID        []byte
+	PublicKey []byte // COSE_Key encoding
+	SignCount uint32
+	AAGUID    []byte
+}
+
+// CredentialDescriptor identifies a credential in ceremony options
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// CreationOptions is PublicKeyCredentialCreationOptionsJSON, ready for
+// PublicKeyCredential.parseCreationOptionsFromJSON in the browser
+type CreationOptions struct {
+	RP struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"rp"`
+	User struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		DisplayName string `json:"displayName"`
+	} `json:"user"`
+	Challenge        string `json:"challenge"`
+	PubKeyCredParams []struct {
+		Type string `json:"type"`
+		Alg  int    `json:"alg"`
+	} `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection struct {
+		ResidentKey      string `json:"residentKey"`
+		UserVerification string `json:"userVerification"`
+	} `json:"authenticatorSelection"`
+	Attestation string `json:"attestation"`
+}
+
+// RequestOptions is PublicKeyCredentialRequestOptionsJSON, ready for
+// PublicKeyCredential.parseRequestOptionsFromJSON in the browser
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	RPID             string                 `json:"rpId"`
+	Timeout          int64                  `json:"timeout"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// AttestationResponse is the JSON form of a registration PublicKeyCredential, as produced by
+// PublicKeyCredential.toJSON(). Fields that verification does not use are declared so that
+// requests rejecting unknown fields accept them.
+type AttestationResponse struct {
+	ID                      string          `json:"id"`
+	RawID                   string          `json:"rawId"`
+	Type                    string          `json:"type"`
+	AuthenticatorAttachment string          `json:"authenticatorAttachment,omitempty"`
+	ClientExtensionResults  json.RawMessage `json:"clientExtensionResults,omitempty"`
+	Response                struct {
+		ClientDataJSON     string   `json:"clientDataJSON"`
+		AttestationObject  string   `json:"attestationObject"`
+		Transports         []string `json:"transports"`
+		AuthenticatorData  string   `json:"authenticatorData,omitempty"`
+		PublicKey          string   `json:"publicKey,omitempty"`
+		PublicKeyAlgorithm int64    `json:"publicKeyAlgorithm,omitempty"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the JSON form of an authentication PublicKeyCredential, as produced by
+// PublicKeyCredential.toJSON()
+type AssertionResponse struct {
+	ID                      string          `json:"id"`
+	RawID                   string          `json:"rawId"`
+	Type                    string          `json:"type"`
+	AuthenticatorAttachment string          `json:"authenticatorAttachment,omitempty"`
+	ClientExtensionResults  json.RawMessage `json:"clientExtensionResults,omitempty"`
+	Response                struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle"`
+	} `json:"response"`
+}
+
+// NewChallenge returns a random base64url encoded challenge
+func NewChallenge() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate challenge: %w", err)
+	}
+	return URLEncoding.EncodeToString(buf), nil
+}
+
+// CreationOptions builds registration options for a user. Passkeys must be discoverable and user
+// verifying; attestation is not requested.
+func (rp *RelyingParty) CreationOptions(challenge string, userHandle []byte, name, displayName string, exclude []CredentialDescriptor) *CreationOptions {
+	opts := &CreationOptions{
+		Challenge:          challenge,
+		Timeout:            Timeout.Milliseconds(),
+		ExcludeCredentials: exclude,
+		Attestation:        "none",
+	}
+	opts.RP.ID = rp.ID
+	opts.RP.Name = rp.Name
+	opts.User.ID = URLEncoding.EncodeToString(userHandle)
+	opts.User.Name = name
+	opts.User.DisplayName = displayName
+	for _, alg := range []int{AlgES256, AlgEdDSA, AlgRS256} {
+		opts.PubKeyCredParams = append(opts.PubKeyCredParams, struct {
+			Type string `json:"type"`
+			Alg  int    `json:"alg"`
+		}{"public-key", alg})
+	}
+	opts.AuthenticatorSelection.ResidentKey = "required"
+	opts.AuthenticatorSelection.UserVerification = "required"
+
+	if opts.ExcludeCredentials == nil {
+		opts.ExcludeCredentials = []CredentialDescriptor{}
+	}
+
+	return opts
+}
+
+// RequestOptions builds options for a passwordless login with a discoverable credential
+func (rp *RelyingParty) RequestOptions(challenge string) *RequestOptions {
+	return &RequestOptions{
+		Challenge:        challenge,
+		RPID:             rp.ID,
+		Timeout:          Timeout.Milliseconds(),
+		AllowCredentials: []CredentialDescriptor{},
+		UserVerification: "required",
+	}
+}
+
+// VerifyRegistration checks a registration response against the challenge it was issued for
+// (WebAuthn Level 2, section 7.1) and returns the new credential. Attestation statements are not
+// verified: no attestation is requested, so the authenticator model is not trusted either way.
+func (rp *RelyingParty) VerifyRegistration(resp *AttestationResponse, challenge string) (*Credential, error) {
+	clientData, err := URLEncoding.DecodeString(resp.Response.ClientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: client data is not base64url", ErrInvalidCredential)
+	}
+	if err := rp.verifyClientData(clientData, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	attestationObject, err := URLEncoding.DecodeString(resp.Response.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: attestation object is not base64url", ErrInvalidCredential)
+	}
+	decoded, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredential, err)
+	}
+	attestation, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: attestation object is not a map", ErrInvalidCredential)
+	}
+	authData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing authenticator data", ErrInvalidCredential)
+	}
+
+	flags, signCount, rest, err := rp.verifyAuthenticatorData(authData)
+	if err != nil {
+		return nil, err
+	}
+	if flags&flagAttestedData == 0 || len(rest) < 18 {
+		return nil, fmt.Errorf("%w: missing attested credential data", ErrInvalidCredential)
+	}
+
+	aaguid := rest[:16]
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if idLength == 0 || idLength > 1023 || len(rest) < idLength {
+		return nil, fmt.Errorf("%w: invalid credential ID", ErrInvalidCredential)
+	}
+	credentialID := rest[:idLength]
+	rest = rest[idLength:]
+
+	// The public key is followed by extension data, if any
+	_, extensions, err := decodeCBOR(rest)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredential, err)
+	}
+	coseKey := rest[:len(rest)-len(extensions)]
+	if _, err := parsePublicKey(coseKey); err != nil {
+		return nil, err
+	}
+
+	if rawID, err := URLEncoding.DecodeString(resp.RawID); err != nil || !bytes.Equal(rawID, credentialID) {
+		return nil, fmt.Errorf("%w: credential ID mismatch", ErrInvalidCredential)
+	}
+
+	return &Credential{
+		ID:        append([]byte(nil), credentialID...),
+		PublicKey: append([]byte(nil), coseKey...),
+		SignCount: signCount,
+		AAGUID:    append([]byte(nil), aaguid...),
+	}, nil
+}
+
+// VerifyAssertion checks an authentication response for a stored credential against the
+// challenge it was issued for (WebAuthn Level 2, section 7.2) and returns the new signature counter
+func (rp *RelyingParty) VerifyAssertion(resp *AssertionResponse, challenge string, coseKey []byte, storedSignCount uint32) (uint32, error) {
+	clientData, err := URLEncoding.DecodeString(resp.Response.ClientDataJSON)
+	if err != nil {
+		return 0, fmt.Errorf("%w: client data is not base64url", ErrInvalidCredential)
+	}
+	if err := rp.verifyClientData(clientData, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+
+	authData, err := URLEncoding.DecodeString(resp.Response.AuthenticatorData)
+	if err != nil {
+		return 0, fmt.Errorf("%w: authenticator data is not base64url", ErrInvalidCredential)
+	}
+	_, signCount, _, err := rp.verifyAuthenticatorData(authData)
+	if err != nil {
+		return 0, err
+	}
+
+	signature, err := URLEncoding.DecodeString(resp.Response.Signature)
+	if err != nil {
+		return 0, fmt.Errorf("%w: signature is not base64url", ErrInvalidCredential)
+	}
+
+	key, err := parsePublicKey(coseKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientData)
+	if !key.verify(append(append([]byte(nil), authData...), clientDataHash[:]...), signature) {
+		return 0, fmt.Errorf("%w: bad signature", ErrInvalidCredential)
+	}
+
+	// Authenticators without a counter always report zero
+	if (signCount != 0 || storedSignCount != 0) && signCount <= storedSignCount {
+		return 0, ErrClonedCredential
+	}
+
+	return signCount, nil
+}
+
+// verifyClientData checks the type, challenge and origin recorded by the browser
+func (rp *RelyingParty) verifyClientData(data []byte, ceremony, challenge string) error {
+	var clientData struct {
+		Type        string `json:"type"`
+		Challenge   string `json:"challenge"`
+		Origin      string `json:"origin"`
+		CrossOrigin bool   `json:"crossOrigin"`
+	}
+	if err := json.Unmarshal(data, &clientData); err != nil {
+		return fmt.Errorf("%w: malformed client data", ErrInvalidCredential)
+	}
+
+	if clientData.Type != ceremony {
+		return fmt.Errorf("%w: unexpected ceremony type %q", ErrInvalidCredential, clientData.Type)
+	}
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrInvalidCredential)
+	}
+	if clientData.CrossOrigin || !rp.allowedOrigin(clientData.Origin) {
+		return fmt.Errorf("%w: origin %q is not allowed", ErrInvalidCredential, clientData.Origin)
+	}
+
+	return nil
+}
+
+// verifyAuthenticatorData checks the RP ID hash and that the user was present and verified,
+// returning the flags, signature counter and the remaining attested credential and extension data
+func (rp *RelyingParty) verifyAuthenticatorData(data []byte) (byte, uint32, []byte, error) {
+	if len(data) < 37 {
+		return 0, 0, nil, fmt.Errorf("%w: authenticator data too short", ErrInvalidCredential)
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(data[:32], rpIDHash[:]) {
+		return 0, 0, nil, fmt.Errorf("%w: credential belongs to another relying party", ErrInvalidCredential)
+	}
+
+	flags := data[32]
+	if flags&flagUserPresent == 0 || flags&flagUserVerified == 0 {
+		return 0, 0, nil, fmt.Errorf("%w: user was not verified", ErrInvalidCredential)
+	}
+
+	return flags, binary.BigEndian.Uint32(data[33:37]), data[37:], nil
+}
+
+func (rp *RelyingParty) allowedOrigin(origin string) bool {
+	for _, allowed := range rp.Origins {
+		if origin == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const testChallenge = "dGVzdCBjaGFsbGVuZ2U"
+
+var testRP = &RelyingParty{ID: "example.com", Name: "Example", Origins: []string{"https://example.com"}}
+
+func clientDataJSON(ceremony, challenge, origin string) string {
+	data, _ := json.Marshal(map[string]interface{}{"type": ceremony, "challenge": challenge, "origin": origin})
+	return URLEncoding.EncodeToString(data)
+}
+
+// authenticatorData builds authenticator data for rpID with flags, a signature counter and
+// attested credential and extension data
+func authenticatorData(rpID string, flags byte, signCount uint32, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, signCount)
+	return append(data, attested...)
+}
+
+// attestedCredential builds attested credential data for a credential ID and COSE key
+func attestedCredential(credentialID, coseKey []byte) []byte {
+	data := make([]byte, 16)
+	data = binary.BigEndian.AppendUint16(data, uint16(len(credentialID)))
+	data = append(data, credentialID...)
+	return append(data, coseKey...)
+}
+
+func attestationObject(authData interface{}) string {
+	return URLEncoding.EncodeToString(encodeCBOR(cborMap{
+		{"fmt", "none"},
+		{"attStmt", cborMap{}},
+		{"authData", authData},
+	}))
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coseKey := es256Key(&ecKey.PublicKey)
+	credentialID := []byte("credential-1")
+	rawID := URLEncoding.EncodeToString(credentialID)
+	flags := byte(flagUserPresent | flagUserVerified | flagAttestedData)
+
+	validClientData := clientDataJSON("webauthn.create", testChallenge, "https://example.com")
+	validAuthData := authenticatorData("example.com", flags, 7, attestedCredential(credentialID, coseKey))
+	withExtensions := authenticatorData("example.com", flags|0x80, 7,
+		append(attestedCredential(credentialID, coseKey), encodeCBOR(cborMap{{"credProtect", 2}})...))
+
+	tests := []struct {
+		name              string
+		clientData        string
+		attestationObject string
+		rawID             string
+		wantErr           bool
+	}{
+		{name: "valid", clientData: validClientData, attestationObject: attestationObject(validAuthData), rawID: rawID},
+		{name: "with extension data", clientData: validClientData, attestationObject: attestationObject(withExtensions), rawID: rawID},
+		{name: "client data not base64url", clientData: "!!", attestationObject: attestationObject(validAuthData), rawID: rawID, wantErr: true},
+		{name: "client data not json", clientData: URLEncoding.EncodeToString([]byte("{")), attestationObject: attestationObject(validAuthData), rawID: rawID, wantErr: true},
+		{name: "assertion client data", clientData: clientDataJSON("webauthn.get", testChallenge, "https://example.com"), attestationObject: attestationObject(validAuthData), rawID: rawID, wantErr: true},
+		{name: "other challenge", clientData: clientDataJSON("webauthn.create", "b3RoZXI", "https://example.com"), attestationObject: attestationObject(validAuthData), rawID: rawID, wantErr: true},
+		{name: "other origin", clientData: clientDataJSON("webauthn.create", testChallenge, "https://evil.example"), attestationObject: attestationObject(validAuthData), rawID: rawID, wantErr: true},
+		{name: "attestation object not base64url", clientData: validClientData, attestationObject: "!!", rawID: rawID, wantErr: true},
+		{name: "attestation object not cbor", clientData: validClientData, attestationObject: URLEncoding.EncodeToString([]byte{0xbf}), rawID: rawID, wantErr: true},
+		{name: "attestation object not a map", clientData: validClientData, attestationObject: URLEncoding.EncodeToString(encodeCBOR("none")), rawID: rawID, wantErr: true},
+		{name: "missing authenticator data", clientData: validClientData, attestationObject: URLEncoding.EncodeToString(encodeCBOR(cborMap{{"fmt", "none"}})), rawID: rawID, wantErr: true},
+		{name: "authenticator data not bytes", clientData: validClientData, attestationObject: attestationObject("authData"), rawID: rawID, wantErr: true},
+		{name: "authenticator data too short", clientData: validClientData, attestationObject: attestationObject(validAuthData[:36]), rawID: rawID, wantErr: true},
+		{name: "other relying party", clientData: validClientData, attestationObject: attestationObject(authenticatorData("evil.example", flags, 7, attestedCredential(credentialID, coseKey))), rawID: rawID, wantErr: true},
+		{name: "user not verified", clientData: validClientData, attestationObject: attestationObject(authenticatorData("example.com", flagUserPresent|flagAttestedData, 7, attestedCredential(credentialID, coseKey))), rawID: rawID, wantErr: true},
+		{name: "no attested credential", clientData: validClientData, attestationObject: attestationObject(authenticatorData("example.com", flagUserPresent|flagUserVerified, 7, nil)), rawID: rawID, wantErr: true},
+		{name: "truncated attested credential", clientData: validClientData, attestationObject: attestationObject(validAuthData[:37+17]), rawID: rawID, wantErr: true},
+		{name: "empty credential id", clientData: validClientData, attestationObject: attestationObject(authenticatorData("example.com", flags, 7, attestedCredential(nil, coseKey))), rawID: rawID, wantErr: true},
+		{name: "credential id exceeds data", clientData: validClientData, attestationObject: attestationObject(validAuthData[:37+18+4]), rawID: rawID, wantErr: true},
+		{name: "missing public key", clientData: validClientData, attestationObject: attestationObject(authenticatorData("example.com", flags, 7, attestedCredential(credentialID, nil))), rawID: rawID, wantErr: true},
+		{name: "unsupported public key", clientData: validClientData, attestationObject: attestationObject(authenticatorData("example.com", flags, 7, attestedCredential(credentialID, encodeCBOR(cborMap{{coseKeyType, 4}})))), rawID: rawID, wantErr: true},
+		{name: "raw id mismatch", clientData: validClientData, attestationObject: attestationObject(validAuthData), rawID: URLEncoding.EncodeToString([]byte("credential-2")), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &AttestationResponse{ID: tt.rawID, RawID: tt.rawID, Type: "public-key"}
+			resp.Response.ClientDataJSON = tt.clientData
+			resp.Response.AttestationObject = tt.attestationObject
+
+			credential, err := testRP.VerifyRegistration(resp, testChallenge)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("VerifyRegistration() succeeded, want an error")
+				}
+				if !errors.Is(err, ErrInvalidCredential) && !errors.Is(err, ErrUnsupportedKey) {
+					t.Errorf("VerifyRegistration() error = %v, want %v or %v", err, ErrInvalidCredential, ErrUnsupportedKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyRegistration() error = %v", err)
+			}
+			if string(credential.ID) != string(credentialID) || string(credential.PublicKey) != string(coseKey) || credential.SignCount != 7 {
+				t.Errorf("VerifyRegistration() = %+v, want credential %q with its key and counter 7", credential, credentialID)
+			}
+		})
+	}
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coseKey := es256Key(&ecKey.PublicKey)
+	flags := byte(flagUserPresent | flagUserVerified)
+
+	sign := func(authData []byte, clientData string) string {
+		decoded, _ := URLEncoding.DecodeString(clientData)
+		clientDataHash := sha256.Sum256(decoded)
+		digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+		signature, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return URLEncoding.EncodeToString(signature)
+	}
+
+	validClientData := clientDataJSON("webauthn.get", testChallenge, "https://example.com")
+	authData := authenticatorData("example.com", flags, 8, nil)
+	zeroCounter := authenticatorData("example.com", flags, 0, nil)
+
+	tests := []struct {
+		name       string
+		clientData string
+		authData   []byte
+		signature  string
+		stored     uint32
+		want       uint32
+		wantErr    error
+	}{
+		{name: "valid", clientData: validClientData, authData: authData, signature: sign(authData, validClientData), stored: 7, want: 8},
+		{name: "authenticator without counter", clientData: validClientData, authData: zeroCounter, signature: sign(zeroCounter, validClientData), stored: 0, want: 0},
+		{name: "counter did not advance", clientData: validClientData, authData: authData, signature: sign(authData, validClientData), stored: 8, wantErr: ErrClonedCredential},
+		{name: "counter went backwards", clientData: validClientData, authData: authData, signature: sign(authData, validClientData), stored: 9, wantErr: ErrClonedCredential},
+		{name: "registration client data", clientData: clientDataJSON("webauthn.create", testChallenge, "https://example.com"), authData: authData, signature: sign(authData, validClientData), wantErr: ErrInvalidCredential},
+		{name: "signature over other data", clientData: validClientData, authData: authData, signature: sign(zeroCounter, validClientData), wantErr: ErrInvalidCredential},
+		{name: "signature not base64url", clientData: validClientData, authData: authData, signature: "!!", wantErr: ErrInvalidCredential},
+		{name: "user not present", clientData: validClientData, authData: authenticatorData("example.com", flagUserVerified, 8, nil), signature: sign(authData, validClientData), wantErr: ErrInvalidCredential},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &AssertionResponse{Type: "public-key"}
+			resp.Response.ClientDataJSON = tt.clientData
+			resp.Response.AuthenticatorData = URLEncoding.EncodeToString(tt.authData)
+			resp.Response.Signature = tt.signature
+
+			got, err := testRP.VerifyAssertion(resp, testChallenge, coseKey, tt.stored)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("VerifyAssertion() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyAssertion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("VerifyAssertion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+-- Create roles table
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create permissions table
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create role to permission mapping
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_name VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission_name VARCHAR(100) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_name, permission_name)
+);
+
+-- Create user to role mapping
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_name VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_name)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_name ON user_roles(role_name);
+
+-- Insert default roles and permissions
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full administrative access'),
+    ('user', 'Regular user')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:read', 'View any user account'),
+    ('users:write', 'Modify any user account'),
+    ('roles:manage', 'Assign and remove roles')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+    ('admin', 'users:read'),
+    ('admin', 'users:write'),
+    ('admin', 'roles:manage')
+ON CONFLICT DO NOTHING;
+
+-- Give every existing user the regular user role
+INSERT INTO user_roles (user_id, role_name)
+SELECT id, 'user' FROM users
+ON CONFLICT DO NOTHING;
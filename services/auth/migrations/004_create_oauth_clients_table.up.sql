@@ -0,0 +1,20 @@
+-- Create registered OAuth2 / OpenID Connect clients
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    id VARCHAR(64) PRIMARY KEY,
+    secret_hash VARCHAR(255), -- NULL for public clients, which must use PKCE
+    name VARCHAR(255) NOT NULL,
+    redirect_uris TEXT[] NOT NULL DEFAULT '{}',
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    active BOOLEAN DEFAULT true
+);
+
+-- Insert default public client (for development only)
+INSERT INTO oauth_clients (id, name, redirect_uris, scopes)
+VALUES (
+    'local-dev-spa',
+    'Local development SPA',
+    ARRAY['http://localhost:3000/callback'],
+    ARRAY['openid', 'profile', 'email', 'offline_access']
+) ON CONFLICT (id) DO NOTHING;
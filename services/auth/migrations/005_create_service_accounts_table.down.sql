@@ -0,0 +1,5 @@
+-- Remove the service account permission
+DELETE FROM permissions WHERE name = 'service_accounts:manage';
+
+-- Drop service accounts table
+DROP TABLE IF EXISTS service_accounts;
@@ -0,0 +1,20 @@
+-- Create service accounts used by other services to call the auth API as themselves
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id VARCHAR(36) PRIMARY KEY,
+    client_id VARCHAR(64) UNIQUE NOT NULL,
+    secret_hash VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    active BOOLEAN DEFAULT true
+);
+
+-- Allow admins to manage service accounts
+INSERT INTO permissions (name, description) VALUES
+    ('service_accounts:manage', 'Create, rotate and delete service accounts')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+    ('admin', 'service_accounts:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+-- Drop email verification tokens table
+DROP TABLE IF EXISTS email_verification_tokens;
+
+-- Remove the verification state
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified;
@@ -0,0 +1,2 @@
+-- Drop WebAuthn credentials table
+DROP TABLE IF EXISTS webauthn_credentials;
@@ -0,0 +1,15 @@
+-- Create WebAuthn credentials (passkeys); the ID is the base64url encoded credential ID
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id VARCHAR(1400) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    aaguid VARCHAR(36),
+    transports TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP
+);
+
+-- Create index for listing a user's passkeys
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials(user_id);
@@ -0,0 +1,7 @@
+-- Drop the audit log permission
+DELETE FROM role_permissions WHERE permission_name = 'audit:read';
+DELETE FROM permissions WHERE name = 'audit:read';
+
+-- Drop the audit log
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
@@ -0,0 +1,41 @@
+-- Create the security audit log. User IDs are not foreign keys so that events outlive the
+-- accounts they mention.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id VARCHAR(36) PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    outcome VARCHAR(16) NOT NULL,
+    actor_id VARCHAR(36),
+    subject_id VARCHAR(36),
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    request_id VARCHAR(255),
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for the audit query API
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events(actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_subject_id ON audit_events(subject_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_event_type ON audit_events(event_type, created_at);
+
+-- Keep the audit log append-only
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+-- Allow admins to read the audit log
+INSERT INTO permissions (name, description) VALUES
+    ('audit:read', 'Query the security audit log')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+    ('admin', 'audit:read')
+ON CONFLICT DO NOTHING;
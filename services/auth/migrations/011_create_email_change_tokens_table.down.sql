@@ -0,0 +1,2 @@
+-- Drop email change tokens table
+DROP TABLE IF EXISTS email_change_tokens;
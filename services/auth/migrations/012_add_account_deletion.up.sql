@@ -0,0 +1,24 @@
+-- Track accounts whose owners asked for them to be deleted. The account is deleted by a
+-- background job once deletion_scheduled_at has passed.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP;
+
+-- Create index for the deletion job
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON users(deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL;
+
+-- Keep the audit log append-only, except that the client details of deleted users may be erased
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND NEW.ip_address IS NULL
+        AND NEW.user_agent IS NULL
+        AND (NEW.id, NEW.event_type, NEW.outcome, NEW.actor_id, NEW.subject_id, NEW.request_id, NEW.details, NEW.created_at)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.event_type, OLD.outcome, OLD.actor_id, OLD.subject_id, OLD.request_id, OLD.details, OLD.created_at)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
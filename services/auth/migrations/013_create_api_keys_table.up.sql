@@ -0,0 +1,18 @@
+-- Create personal API keys. Keys are identified by their prefix; only a SHA-256 hash of each
+-- key is stored.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(32) UNIQUE NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP
+);
+
+-- Create index for listing a user's keys
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
@@ -0,0 +1 @@
+-- The original spelling of normalized email addresses is not kept, so there is nothing to undo
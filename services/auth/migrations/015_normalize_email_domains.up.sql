@@ -0,0 +1,21 @@
+-- Requests now have surrounding whitespace trimmed from email addresses and their domain, which
+-- is case-insensitive, lower-cased. Store existing addresses the same way so their owners can
+-- still sign in. When several accounts normalize to the same address, only the oldest is changed.
+WITH candidates AS (
+    SELECT id, created_at, email,
+        left(btrim(email), length(btrim(email)) - strpos(reverse(btrim(email)), '@'))
+            || lower(right(btrim(email), strpos(reverse(btrim(email)), '@'))) AS normalized
+    FROM users
+    WHERE strpos(email, '@') > 0
+),
+changes AS (
+    SELECT DISTINCT ON (normalized) id, normalized
+    FROM candidates c
+    WHERE normalized <> email
+        AND NOT EXISTS (SELECT 1 FROM users u WHERE u.email = c.normalized)
+    ORDER BY normalized, created_at
+)
+UPDATE users
+SET email = changes.normalized
+FROM changes
+WHERE users.id = changes.id;